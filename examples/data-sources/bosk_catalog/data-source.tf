@@ -0,0 +1,13 @@
+data "bosk_catalog" "planets" {
+  path = "/worlds"
+  filter {
+    pointer    = "/kind"
+    value_json = jsonencode("planet")
+  }
+}
+
+resource "bosk_node" "planet_status" {
+  for_each   = data.bosk_catalog.planets.entries_json
+  path       = "/status/${each.key}"
+  value_json = jsonencode({ id = each.key, kind = jsondecode(each.value).kind })
+}
@@ -0,0 +1,19 @@
+data "bosk_node_condition" "service_ready" {
+  path                = "/services/checkout"
+  pointer             = "/status/ready"
+  expected_value_json = jsonencode(true)
+  interval            = "10s"
+
+  timeouts {
+    read = "5m"
+  }
+
+  depends_on = [bosk_catalog_entry.checkout]
+}
+
+resource "bosk_node" "checkout_routing" {
+  path       = "/routing/checkout"
+  value_json = jsonencode({ id = "checkout", enabled = true })
+
+  depends_on = [data.bosk_node_condition.service_ready]
+}
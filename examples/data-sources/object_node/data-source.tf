@@ -1,4 +1,4 @@
 data "object-node" "example" {
-  url        = "http://localhost:1740/bosk/"
-  value_json = "[{\"world\":{\"id\":\"world\"}}]"
+  url     = "http://localhost:1740/bosk/"
+  pointer = "/world/id"
 }
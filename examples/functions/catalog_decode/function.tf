@@ -0,0 +1,7 @@
+data "bosk_node" "worlds" {
+  path = "/worlds"
+}
+
+output "world_kinds" {
+  value = { for id, world in provider::bosk::catalog_decode(data.bosk_node.worlds.value_json) : id => jsondecode(world).kind }
+}
@@ -0,0 +1,7 @@
+resource "bosk_node" "worlds" {
+  path = "/worlds"
+  value_json = provider::bosk::catalog_encode({
+    earth = jsonencode({ id = "earth", kind = "planet" })
+    luna  = jsonencode({ id = "luna", kind = "moon" })
+  })
+}
@@ -0,0 +1,4 @@
+resource "bosk_node" "planets" {
+  path       = "/planets"
+  value_json = provider::bosk::listing_encode(["earth", "mars"], provider::bosk::reference("worlds"))
+}
@@ -0,0 +1,6 @@
+resource "bosk_node" "home" {
+  path = "/home"
+  value_json = jsonencode({
+    world = provider::bosk::reference("worlds", "earth")
+  })
+}
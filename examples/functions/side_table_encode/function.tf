@@ -0,0 +1,7 @@
+resource "bosk_node" "colours" {
+  path = "/colours"
+  value_json = provider::bosk::side_table_encode("/worlds", {
+    earth = jsonencode("blue")
+    mars  = jsonencode("red")
+  })
+}
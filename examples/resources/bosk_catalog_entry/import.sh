@@ -0,0 +1,3 @@
+# Catalog entries are imported as <catalog>/<id>, where <catalog> is
+# either a URL or a path relative to the provider's base_url
+terraform import bosk_catalog_entry.earth /worlds/earth
@@ -0,0 +1,8 @@
+resource "bosk_catalog_entry" "earth" {
+  catalog_path = "/worlds"
+  id           = "earth"
+  value_json = jsonencode({
+    id   = "earth"
+    name = "Earth"
+  })
+}
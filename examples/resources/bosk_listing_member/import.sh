@@ -0,0 +1,3 @@
+# Listing members are imported as <listing>/<id>, where <listing> is
+# either a URL or a path relative to the provider's base_url
+terraform import bosk_listing_member.earth /visited/earth
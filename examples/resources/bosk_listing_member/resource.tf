@@ -0,0 +1,4 @@
+resource "bosk_listing_member" "earth" {
+  listing_path = "/visited"
+  id           = "earth"
+}
@@ -0,0 +1,3 @@
+# Side table entries are imported as <side table>/<id>, where <side table> is
+# either a URL or a path relative to the provider's base_url
+terraform import bosk_side_table_entry.earth_settings /worldSettings/earth
@@ -0,0 +1,7 @@
+resource "bosk_side_table_entry" "earth_settings" {
+  side_table_path = "/worldSettings"
+  id              = "earth"
+  value_json = jsonencode({
+    gravity = 9.8
+  })
+}
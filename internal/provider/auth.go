@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Authenticator adds credentials to requests sent by a BoskClient.
+type Authenticator interface {
+	// Authenticate adds credentials to the request, obtaining them first if necessary.
+	Authenticate(ctx context.Context, req *http.Request) error
+
+	// Unauthorized is called when the server rejects credentials added by Authenticate
+	// with 401 Unauthorized. Returns true if the request is worth retrying with fresh credentials.
+	Unauthorized(req *http.Request) bool
+}
+
+var _ Authenticator = &BasicAuth{}
+var _ Authenticator = &BearerAuth{}
+var _ Authenticator = &ClientCredentialsAuth{}
+
+// BasicAuth sends a username and password using HTTP basic authentication.
+type BasicAuth struct {
+	username string
+	password string
+}
+
+func NewBasicAuth(username string, password string) *BasicAuth {
+	return &BasicAuth{
+		username: username,
+		password: password,
+	}
+}
+
+func (auth *BasicAuth) Authenticate(ctx context.Context, req *http.Request) error {
+	req.SetBasicAuth(auth.username, auth.password)
+	return nil
+}
+
+func (auth *BasicAuth) Unauthorized(req *http.Request) bool {
+	return false
+}
+
+// BearerAuth sends a fixed bearer token.
+type BearerAuth struct {
+	token string
+}
+
+func NewBearerAuth(token string) *BearerAuth {
+	return &BearerAuth{
+		token: token,
+	}
+}
+
+func (auth *BearerAuth) Authenticate(ctx context.Context, req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+auth.token)
+	return nil
+}
+
+func (auth *BearerAuth) Unauthorized(req *http.Request) bool {
+	return false
+}
+
+// ClientCredentialsAuth obtains bearer tokens using the OAuth2 client credentials grant (RFC 6749 section 4.4).
+// Tokens are cached until shortly before they expire, or until the server rejects them.
+type ClientCredentialsAuth struct {
+	httpClient   *http.Client
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scopes       []string
+
+	mutex  sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// tokenExpiryMargin is how long before its stated expiry a token is considered stale,
+// so that it doesn't expire while a request is in flight.
+const tokenExpiryMargin = 30 * time.Second
+
+func NewClientCredentialsAuth(httpClient *http.Client, tokenURL string, clientID string, clientSecret string, scopes []string) *ClientCredentialsAuth {
+	return &ClientCredentialsAuth{
+		httpClient:   httpClient,
+		tokenURL:     tokenURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		scopes:       scopes,
+	}
+}
+
+func (auth *ClientCredentialsAuth) Authenticate(ctx context.Context, req *http.Request) error {
+	token, err := auth.currentToken(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Unauthorized discards the cached token if it's the one the server rejected,
+// so the retry fetches a new one.
+func (auth *ClientCredentialsAuth) Unauthorized(req *http.Request) bool {
+	auth.mutex.Lock()
+	defer auth.mutex.Unlock()
+	if req.Header.Get("Authorization") == "Bearer "+auth.token {
+		auth.token = ""
+	}
+	return true
+}
+
+func (auth *ClientCredentialsAuth) currentToken(ctx context.Context) (string, error) {
+	auth.mutex.Lock()
+	defer auth.mutex.Unlock()
+	if auth.token != "" && (auth.expiry.IsZero() || time.Now().Before(auth.expiry)) {
+		return auth.token, nil
+	}
+	token, expiresIn, err := auth.requestToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	auth.token = token
+	if expiresIn > 0 {
+		auth.expiry = time.Now().Add(expiresIn - minDuration(tokenExpiryMargin, expiresIn/2))
+	} else {
+		auth.expiry = time.Time{}
+	}
+	return token, nil
+}
+
+// tokenResponse is the successful token response of RFC 6749 section 5.1.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// tokenErrorResponse is the error response of RFC 6749 section 5.2.
+type tokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (auth *ClientCredentialsAuth) requestToken(ctx context.Context) (string, time.Duration, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(auth.scopes) > 0 {
+		form.Set("scope", strings.Join(auth.scopes, " "))
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", auth.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(auth.clientID), url.QueryEscape(auth.clientSecret))
+
+	httpResp, err := auth.httpClient.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to obtain OAuth2 token from %v: %w", auth.tokenURL, err)
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(httpResp.Body, 1<<20))
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to read OAuth2 token response from %v: %w", auth.tokenURL, err)
+	}
+	if httpResp.StatusCode/100 != 2 {
+		var errorResponse tokenErrorResponse
+		if json.Unmarshal(body, &errorResponse) == nil && errorResponse.Error != "" {
+			return "", 0, fmt.Errorf("OAuth2 token request to %v returned %s: %s %s", auth.tokenURL, httpResp.Status, errorResponse.Error, errorResponse.ErrorDescription)
+		}
+		return "", 0, fmt.Errorf("OAuth2 token request to %v returned unexpected status %s", auth.tokenURL, httpResp.Status)
+	}
+
+	var response tokenResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", 0, fmt.Errorf("unable to parse OAuth2 token response from %v: %w", auth.tokenURL, err)
+	}
+	if response.AccessToken == "" {
+		return "", 0, fmt.Errorf("OAuth2 token response from %v contained no access_token", auth.tokenURL)
+	}
+	if response.TokenType != "" && !strings.EqualFold(response.TokenType, "bearer") {
+		return "", 0, fmt.Errorf("OAuth2 token response from %v has unsupported token_type %q", auth.tokenURL, response.TokenType)
+	}
+	return response.AccessToken, time.Duration(response.ExpiresIn) * time.Second, nil
+}
@@ -0,0 +1,210 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// requireAuthorization wraps a handler, rejecting requests whose Authorization header isn't accepted.
+func requireAuthorization(accepted func(string) bool, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !accepted(r.Header.Get("Authorization")) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// fakeTokenServer issues numbered access tokens using the client credentials grant.
+type fakeTokenServer struct {
+	t         *testing.T
+	mutex     sync.Mutex
+	issued    int
+	expiresIn int
+}
+
+func (f *fakeTokenServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok || clientID != "client" || clientSecret != "s3cr3t" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+		return
+	}
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("scope") != "read write" {
+		f.t.Errorf("unexpected token request form: %v", r.PostForm)
+	}
+	f.issued++
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": fmt.Sprintf("token-%d", f.issued),
+		"token_type":   "Bearer",
+		"expires_in":   f.expiresIn,
+	})
+}
+
+func (f *fakeTokenServer) tokensIssued() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.issued
+}
+
+func TestBearerAuth(t *testing.T) {
+	fake := newFakeBoskHandler(t)
+	fake.set("/bosk/node", `{}`)
+	server := httptest.NewServer(requireAuthorization(func(header string) bool { return header == "Bearer abc" }, fake))
+	defer server.Close()
+
+	var diags diag.Diagnostics
+	NewBoskClient(http.DefaultClient, BoskClientSettings{}, NewBearerAuth("abc")).GetJSONAsString(context.Background(), server.URL+"/bosk/node", &diags)
+	if diags.HasError() {
+		t.Errorf("unexpected error: %v", diags)
+	}
+
+	diags = nil
+	NewBoskClient(http.DefaultClient, BoskClientSettings{}, NewBearerAuth("wrong")).GetJSONAsString(context.Background(), server.URL+"/bosk/node", &diags)
+	if !diags.HasError() {
+		t.Errorf("expected wrong token to be rejected")
+	}
+}
+
+func TestClientCredentialsAuthCachesToken(t *testing.T) {
+	tokens := &fakeTokenServer{t: t, expiresIn: 3600}
+	tokenServer := httptest.NewServer(tokens)
+	defer tokenServer.Close()
+	fake := newFakeBoskHandler(t)
+	fake.set("/bosk/node", `{}`)
+	server := httptest.NewServer(requireAuthorization(func(header string) bool { return header == "Bearer token-1" }, fake))
+	defer server.Close()
+
+	auth := NewClientCredentialsAuth(http.DefaultClient, tokenServer.URL, "client", "s3cr3t", []string{"read", "write"})
+	client := NewBoskClient(http.DefaultClient, BoskClientSettings{}, auth)
+	var diags diag.Diagnostics
+	for i := 0; i < 3; i++ {
+		client.GetJSONAsString(context.Background(), server.URL+"/bosk/node", &diags)
+	}
+	if diags.HasError() {
+		t.Errorf("unexpected error: %v", diags)
+	}
+	if tokens.tokensIssued() != 1 {
+		t.Errorf("expected a single token to be issued; got %d", tokens.tokensIssued())
+	}
+}
+
+func TestClientCredentialsAuthRefreshesRejectedToken(t *testing.T) {
+	tokens := &fakeTokenServer{t: t, expiresIn: 3600}
+	tokenServer := httptest.NewServer(tokens)
+	defer tokenServer.Close()
+	fake := newFakeBoskHandler(t)
+	fake.set("/bosk/node", `{}`)
+	// Simulates the first token being revoked before it expires
+	server := httptest.NewServer(requireAuthorization(func(header string) bool { return header == "Bearer token-2" }, fake))
+	defer server.Close()
+
+	auth := NewClientCredentialsAuth(http.DefaultClient, tokenServer.URL, "client", "s3cr3t", []string{"read", "write"})
+	client := NewBoskClient(http.DefaultClient, BoskClientSettings{}, auth)
+	var diags diag.Diagnostics
+	client.GetJSONAsString(context.Background(), server.URL+"/bosk/node", &diags)
+	if diags.HasError() {
+		t.Errorf("unexpected error: %v", diags)
+	}
+	if tokens.tokensIssued() != 2 {
+		t.Errorf("expected a second token to be issued; got %d", tokens.tokensIssued())
+	}
+}
+
+func TestClientCredentialsAuthGivesUp(t *testing.T) {
+	tokens := &fakeTokenServer{t: t, expiresIn: 3600}
+	tokenServer := httptest.NewServer(tokens)
+	defer tokenServer.Close()
+	fake := newFakeBoskHandler(t)
+	server := httptest.NewServer(requireAuthorization(func(string) bool { return false }, fake))
+	defer server.Close()
+
+	auth := NewClientCredentialsAuth(http.DefaultClient, tokenServer.URL, "client", "s3cr3t", []string{"read", "write"})
+	client := NewBoskClient(http.DefaultClient, BoskClientSettings{}, auth)
+	var diags diag.Diagnostics
+	client.GetNode(context.Background(), server.URL+"/bosk/node", &diags)
+	if !diags.HasError() {
+		t.Errorf("expected an error")
+	}
+	if tokens.tokensIssued() != 2 {
+		t.Errorf("expected exactly one refresh; got %d tokens", tokens.tokensIssued())
+	}
+}
+
+func TestClientCredentialsAuthReportsTokenErrors(t *testing.T) {
+	tokens := &fakeTokenServer{t: t, expiresIn: 3600}
+	tokenServer := httptest.NewServer(tokens)
+	defer tokenServer.Close()
+
+	auth := NewClientCredentialsAuth(http.DefaultClient, tokenServer.URL, "client", "wrong", nil)
+	client := NewBoskClient(http.DefaultClient, BoskClientSettings{}, auth)
+	var diags diag.Diagnostics
+	client.GetNode(context.Background(), tokenServer.URL+"/bosk/node", &diags)
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Authentication Error" {
+		t.Errorf("expected an authentication error; got %v", diags)
+	}
+}
+
+func TestAuthUnknownValuesAreDeferred(t *testing.T) {
+	tests := []struct {
+		auth     AuthModel
+		expected bool
+	}{
+		{AuthModel{Basic: &BasicAuthModel{Username: types.StringValue("user"), Password: types.StringValue("secret")}}, false},
+		{AuthModel{Basic: &BasicAuthModel{Username: types.StringValue("user"), Password: types.StringUnknown()}}, true},
+		{AuthModel{Bearer: &BearerAuthModel{Token: types.StringNull()}}, false},
+		{AuthModel{Bearer: &BearerAuthModel{Token: types.StringUnknown()}}, true},
+		{AuthModel{ClientCredentials: &ClientCredentialsAuthModel{
+			TokenURL:     types.StringValue("https://auth.example.com/token"),
+			ClientID:     types.StringValue("client"),
+			ClientSecret: types.StringValue("secret"),
+			Scopes:       types.ListValueMust(types.StringType, []attr.Value{types.StringUnknown()}),
+		}}, true},
+	}
+	for i, test := range tests {
+		if actual := test.auth.hasUnknownValues(); actual != test.expected {
+			t.Errorf("case %v: expected hasUnknownValues to be %v", i, test.expected)
+		}
+	}
+
+	var diags diag.Diagnostics
+	requiredString(types.StringUnknown(), "", path.Root("auth"), &diags)
+	if diags.HasError() {
+		t.Errorf("expected an unknown value not to be reported as missing; got %v", diags)
+	}
+}
+
+func TestUnconfiguredResourcesReportErrors(t *testing.T) {
+	// As when Configure was deferred because of unknown auth settings
+	resources := []resource.ResourceWithImportState{
+		&NodeResource{},
+		NewCatalogEntryResource().(resource.ResourceWithImportState),
+		NewListingMemberResource().(resource.ResourceWithImportState),
+		NewSideTableEntryResource().(resource.ResourceWithImportState),
+	}
+	for _, r := range resources {
+		var resp resource.ImportStateResponse
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: "http://localhost:1740/bosk/node"}, &resp)
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Provider not yet configured" {
+			t.Errorf("%T: expected an unconfigured provider to be reported; got %v", r, resp.Diagnostics)
+		}
+	}
+}
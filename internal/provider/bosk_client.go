@@ -2,73 +2,238 @@ package provider
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type BoskClient struct {
 	httpClient *http.Client
-	auth       *BasicAuth
+	auth       Authenticator
+	settings   BoskClientSettings
+	claims     *nodeClaims
+
+	// attributes are the ones diagnostics should point at; see withErrorAttributes.
+	attributes errorAttributes
 }
 
-type BasicAuth struct {
-	username string
-	password string
+// BoskClientSettings holds the provider-level configuration that applies to every request.
+type BoskClientSettings struct {
+	// BaseURL is the address against which relative node paths are resolved. May be empty.
+	BaseURL string
+
+	// RevisionHeader names the response header that identifies the revision of a node,
+	// and whose value is sent back in If-Match on subsequent writes. May be empty to disable.
+	RevisionHeader string
+
+	// MaxRetries is the number of times an idempotent request is retried after a transient failure.
+	MaxRetries int
+
+	// RetryMinDelay is the backoff before the first retry, which doubles for each subsequent retry.
+	RetryMinDelay time.Duration
+
+	// RetryMaxDelay caps the backoff, including any delay requested by the server in Retry-After.
+	RetryMaxDelay time.Duration
 }
 
-func NewBoskClientWithoutAuth(httpClient *http.Client) *BoskClient {
+func NewBoskClientWithoutAuth(httpClient *http.Client, settings BoskClientSettings) *BoskClient {
 	return &BoskClient{
 		httpClient: httpClient,
 		auth:       nil,
+		settings:   settings,
+		claims:     &nodeClaims{},
 	}
 }
 
-func NewBoskClient(httpClient *http.Client, username string, password string) *BoskClient {
+func NewBoskClient(httpClient *http.Client, settings BoskClientSettings, auth Authenticator) *BoskClient {
 	return &BoskClient{
 		httpClient: httpClient,
-		auth: &BasicAuth{
-			username: username,
-			password: password,
-		},
+		auth:       auth,
+		settings:   settings,
+		claims:     &nodeClaims{},
 	}
 }
 
-// Portions taken from: https://github.com/hashicorp/terraform-provider-http/blob/main/internal/provider/data_source_http.go
-func (client *BoskClient) GetJSONAsString(url string, diag *diag.Diagnostics) string {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		diag.AddError("Client Error", fmt.Sprintf("Unable to create HTTP request: %s", err))
-		return "ERROR"
+// withErrorAttributes returns a client sharing this one's connections and settings,
+// whose diagnostics point at the given attributes of the resource or data source using it.
+func (client *BoskClient) withErrorAttributes(attributes errorAttributes) *BoskClient {
+	result := *client
+	result.attributes = attributes
+	return &result
+}
+
+// HasBaseURL reports whether the provider was configured with a base_url against which node paths can be resolved.
+func (client *BoskClient) HasBaseURL() bool {
+	return client.settings.BaseURL != ""
+}
+
+// URLForPath joins the given node path onto the provider's base_url.
+// Exactly one slash separates the two, regardless of whether base_url ends with one or path starts with one.
+func (client *BoskClient) URLForPath(path string) string {
+	return strings.TrimSuffix(client.settings.BaseURL, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// do sends a request with the client's authentication, a Content-Type header if there is a body,
+// and an If-Match header if a revision is given.
+// Idempotent requests that fail transiently are retried according to the client's settings.
+// Returns nil, having added an error to diag, if the request could not be completed.
+// Otherwise, the caller is responsible for checking the status and closing the response body.
+func (client *BoskClient) do(ctx context.Context, method string, url string, body []byte, contentType string, revision string, diag *diag.Diagnostics) *http.Response {
+	reauthenticated := false
+	for retry := 0; ; retry++ {
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+		if err != nil {
+			diag.AddError("Client Error", fmt.Sprintf("Unable to create HTTP %v request: %s", method, err))
+			return nil
+		}
+		if client.auth != nil {
+			if err := client.auth.Authenticate(ctx, req); err != nil {
+				diag.AddError("Authentication Error", fmt.Sprintf("Unable to authenticate %v %v: %s", method, url, err))
+				return nil
+			}
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", contentType)
+		}
+		if revision != "" {
+			req.Header.Set("If-Match", revision)
+		}
+
+		httpResp, err := client.httpClient.Do(req)
+		if err == nil && httpResp.StatusCode == http.StatusUnauthorized && client.auth != nil && !reauthenticated && client.auth.Unauthorized(req) {
+			// Credentials may have expired; try once more with fresh ones.
+			// This doesn't count against the retry limit for transient failures.
+			tflog.Debug(ctx, "Retrying bosk request with fresh credentials", map[string]interface{}{
+				"method": method,
+				"url":    url,
+			})
+			_, _ = io.Copy(io.Discard, httpResp.Body)
+			httpResp.Body.Close()
+			reauthenticated = true
+			retry--
+			continue
+		}
+		if retry < client.settings.MaxRetries && isIdempotent(method) && isRetryable(httpResp, err) && ctx.Err() == nil {
+			delay := client.settings.retryDelay(retry, httpResp)
+			fields := map[string]interface{}{
+				"method": method,
+				"url":    url,
+				"retry":  retry + 1,
+				"delay":  delay.String(),
+			}
+			if err != nil {
+				fields["error"] = err.Error()
+			} else {
+				fields["status"] = httpResp.Status
+				// Drain the body so the connection can be reused
+				_, _ = io.Copy(io.Discard, httpResp.Body)
+				httpResp.Body.Close()
+			}
+			tflog.Warn(ctx, "Retrying bosk request", fields)
+			if sleepContext(ctx, delay) {
+				continue
+			}
+			diag.AddError("Client Error", fmt.Sprintf("Gave up retrying %v %v: %s", method, url, ctx.Err()))
+			return nil
+		}
+
+		if err != nil {
+			if ctx.Err() != nil {
+				diag.AddError("Client Error", fmt.Sprintf("%v %v did not complete: %s", method, url, ctx.Err()))
+			} else {
+				diag.AddError("Client Error", fmt.Sprintf("Unable to %v %v: %s", method, url, err))
+			}
+			return nil
+		}
+		return httpResp
 	}
+}
 
-	if client.auth != nil {
-		req.SetBasicAuth(client.auth.username, client.auth.password)
+// checkStatus adds an error to diag and returns false if the response does not indicate success.
+// The error includes whatever explanation the server gave in the response body.
+func (client *BoskClient) checkStatus(httpResp *http.Response, revision string, diag *diag.Diagnostics) bool {
+	method := httpResp.Request.Method
+	url := httpResp.Request.URL
+	if httpResp.StatusCode == http.StatusPreconditionFailed {
+		diag.AddError(
+			"Node changed since last refresh",
+			fmt.Sprintf("%v %v was rejected because the node no longer has revision %v. Someone else has modified it; run terraform refresh or plan again to pick up their change.", method, url, revision),
+		)
+		return false
 	}
-	httpResp, err := client.httpClient.Do(req)
-	if err != nil {
-		diag.AddError("Client Error", fmt.Sprintf("Unable to %v %v: %s", req.Method, url, err))
-		return "ERROR"
+	if httpResp.StatusCode/100 != 2 {
+		message := fmt.Sprintf("%v %v returned unexpected status %s", method, url, httpResp.Status)
+		if detail := readErrorDetail(httpResp); detail != "" {
+			message += "\n\nServer response: " + detail
+		}
+		if attribute, ok := client.attributes.errorAttribute(method, httpResp.StatusCode); ok {
+			diag.AddAttributeError(attribute, "Client Error", message)
+		} else {
+			diag.AddError("Client Error", message)
+		}
+		return false
+	}
+	return true
+}
+
+// revisionOf returns the node revision reported by the given response, or "" if there is none.
+func (client *BoskClient) revisionOf(httpResp *http.Response) string {
+	if client.settings.RevisionHeader == "" {
+		return ""
+	}
+	return httpResp.Header.Get(client.settings.RevisionHeader)
+}
+
+// NodeContents is the result of reading a node that may or may not exist.
+type NodeContents struct {
+	// Found is false if the server reported that there is no node at the requested URL.
+	// In that case, the other fields are empty.
+	Found bool
+
+	// JSON is the normalized JSON representation of the node.
+	JSON string
+
+	// Revision is the node's revision, if the server reported one.
+	Revision string
+}
+
+// Portions taken from: https://github.com/hashicorp/terraform-provider-http/blob/main/internal/provider/data_source_http.go
+//
+// GetNode reads the node at the given URL. A 404 response is not an error; it results in Found being false.
+func (client *BoskClient) GetNode(ctx context.Context, url string, diag *diag.Diagnostics) NodeContents {
+	httpResp := client.do(ctx, "GET", url, nil, "", "", diag)
+	if httpResp == nil {
+		return NodeContents{}
 	}
 
 	defer httpResp.Body.Close()
 
-	if httpResp.StatusCode/100 != 2 {
-		diag.AddError("Client Error", fmt.Sprintf("%v %v returned unexpected status %s", req.Method, url, httpResp.Status))
-		return "ERROR"
+	if httpResp.StatusCode == http.StatusNotFound {
+		return NodeContents{Found: false}
+	}
+	if !client.checkStatus(httpResp, "", diag) {
+		return NodeContents{}
 	}
 
+	revision := client.revisionOf(httpResp)
 	bytes, err := io.ReadAll(httpResp.Body)
 	if err != nil {
 		diag.AddError(
 			"Error reading response body",
 			fmt.Sprintf("Error reading response body: %s", err),
 		)
-		return "ERROR"
+		return NodeContents{}
 	}
 	if !utf8.Valid(bytes) {
 		diag.AddWarning(
@@ -83,67 +248,94 @@ func (client *BoskClient) GetJSONAsString(url string, diag *diag.Diagnostics) st
 			"Error normalizing JSON response",
 			fmt.Sprintf("Error reading response body: %s", err),
 		)
-		return string(bytes)
+		return NodeContents{Found: true, JSON: string(bytes), Revision: revision}
 	}
 
-	return string(normalized)
+	return NodeContents{Found: true, JSON: string(normalized), Revision: revision}
 }
 
-func normalizeJSON(input []byte) ([]byte, error) {
-	var parsed interface{}
-	err := json.Unmarshal(input, &parsed)
-	if err != nil {
-		return input, err
-	}
-	result, err := json.Marshal(parsed)
-	if err != nil {
-		return input, err
+// GetJSONAsString reads a node that is expected to exist.
+// Returns the normalized JSON, and the revision of the node, if the server reported one.
+func (client *BoskClient) GetJSONAsString(ctx context.Context, url string, diag *diag.Diagnostics) (string, string) {
+	contents := client.GetNode(ctx, url, diag)
+	if !contents.Found {
+		if diag.HasError() {
+			return "ERROR", ""
+		}
+		message := fmt.Sprintf("GET %v returned 404 Not Found", url)
+		if attribute, ok := client.attributes.errorAttribute("GET", http.StatusNotFound); ok {
+			diag.AddAttributeError(attribute, "Node not found", message)
+		} else {
+			diag.AddError("Node not found", message)
+		}
+		return "ERROR", ""
 	}
-	return result, nil
+	return contents.JSON, contents.Revision
 }
 
-func (client *BoskClient) PutJSONAsString(url string, value string, diag *diag.Diagnostics) {
-	req, err := http.NewRequest("PUT", url, bytes.NewReader([]byte(value)))
-	if err != nil {
-		diag.AddError("Client Error", fmt.Sprintf("Unable to create HTTP PUT request: %s", err))
-		return
-	}
-	if client.auth != nil {
-		req.SetBasicAuth(client.auth.username, client.auth.password)
-	}
-
-	httpResp, err := client.httpClient.Do(req)
-	if err != nil {
-		diag.AddError("Client Error", fmt.Sprintf("Unable to PUT node: %s", err))
-		return
+// PutJSONAsString sends the given value, conditional on the node still having the given revision, if any.
+// Returns the new revision of the node, if the server reported one.
+func (client *BoskClient) PutJSONAsString(ctx context.Context, url string, value string, revision string, diag *diag.Diagnostics) string {
+	httpResp := client.do(ctx, "PUT", url, []byte(value), "application/json", revision, diag)
+	if httpResp == nil {
+		return ""
 	}
 
 	defer httpResp.Body.Close()
 
-	if httpResp.StatusCode/100 != 2 {
-		diag.AddError("Client Error", fmt.Sprintf("PUT returned unexpected status: %s", httpResp.Status))
+	if !client.checkStatus(httpResp, revision, diag) {
+		return ""
 	}
+	return client.revisionOf(httpResp)
 }
 
-func (client *BoskClient) Delete(url string, diag *diag.Diagnostics) {
-	req, err := http.NewRequest("DELETE", url, nil)
-	if client.auth != nil {
-		req.SetBasicAuth(client.auth.username, client.auth.password)
+// PatchJSONAsString sends the given patch document, of the given content type, conditional on the node still having the given revision, if any.
+// Returns the new revision of the node, if the server reported one, and false without adding an error
+// if the server responds 405 Method Not Allowed, so the caller can fall back to PUT.
+func (client *BoskClient) PatchJSONAsString(ctx context.Context, url string, patch string, contentType string, revision string, diag *diag.Diagnostics) (string, bool) {
+	httpResp := client.do(ctx, "PATCH", url, []byte(patch), contentType, revision, diag)
+	if httpResp == nil {
+		return "", true
 	}
-	if err != nil {
-		diag.AddError("Client Error", fmt.Sprintf("Unable to create HTTP DELETE request: %s", err))
-		return
+
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusMethodNotAllowed {
+		_, _ = io.Copy(io.Discard, httpResp.Body)
+		return "", false
+	}
+	if !client.checkStatus(httpResp, revision, diag) {
+		return "", true
 	}
+	return client.revisionOf(httpResp), true
+}
 
-	httpResp, err := client.httpClient.Do(req)
-	if err != nil {
-		diag.AddError("Client Error", fmt.Sprintf("Unable to DELETE node: %s", err))
+// Delete removes the node, conditional on it still having the given revision, if any.
+// A node that is already gone counts as success.
+func (client *BoskClient) Delete(ctx context.Context, url string, revision string, diag *diag.Diagnostics) {
+	httpResp := client.do(ctx, "DELETE", url, nil, "", revision, diag)
+	if httpResp == nil {
 		return
 	}
 
 	defer httpResp.Body.Close()
 
-	if httpResp.StatusCode/100 != 2 {
-		diag.AddError("Client Error", fmt.Sprintf("DELETE returned unexpected status: %s", httpResp.Status))
+	if httpResp.StatusCode == http.StatusNotFound {
+		return
 	}
+	if httpResp.StatusCode == http.StatusPreconditionFailed && revision != "" {
+		// Servers may evaluate If-Match before discovering the node doesn't exist.
+		// Check whether it's actually been deleted out from under us.
+		if client.isGone(ctx, url) {
+			return
+		}
+	}
+	client.checkStatus(httpResp, revision, diag)
+}
+
+// isGone reports whether the server affirmatively says there's no node at the given URL.
+func (client *BoskClient) isGone(ctx context.Context, url string) bool {
+	var diags diag.Diagnostics
+	contents := client.GetNode(ctx, url, &diags)
+	return !diags.HasError() && !contents.Found
 }
@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestURLForPath(t *testing.T) {
+	tests := []struct {
+		baseURL  string
+		path     string
+		expected string
+	}{
+		{"http://localhost:1740/bosk", "world", "http://localhost:1740/bosk/world"},
+		{"http://localhost:1740/bosk/", "world", "http://localhost:1740/bosk/world"},
+		{"http://localhost:1740/bosk", "/world", "http://localhost:1740/bosk/world"},
+		{"http://localhost:1740/bosk/", "/world/a/b", "http://localhost:1740/bosk/world/a/b"},
+	}
+	for _, test := range tests {
+		client := NewBoskClientWithoutAuth(http.DefaultClient, BoskClientSettings{BaseURL: test.baseURL})
+		actual := client.URLForPath(test.path)
+		if actual != test.expected {
+			t.Errorf("URLForPath(%q) with base %q: expected %q, got %q", test.path, test.baseURL, test.expected, actual)
+		}
+	}
+}
+
+func TestRevisionRoundTrip(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+	fake.set("/bosk/node", `{"id":"node"}`)
+
+	client := NewBoskClientWithoutAuth(http.DefaultClient, BoskClientSettings{RevisionHeader: "ETag"})
+	url := testServer.URL + "/bosk/node"
+	var diags diag.Diagnostics
+	ctx := context.Background()
+
+	_, revision := client.GetJSONAsString(ctx, url, &diags)
+	if revision == "" {
+		t.Fatalf("expected GET to report a revision")
+	}
+	newRevision := client.PutJSONAsString(ctx, url, `{"id":"node","x":1}`, revision, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if newRevision == "" || newRevision == revision {
+		t.Errorf("expected PUT to report a new revision; got %q after %q", newRevision, revision)
+	}
+	client.Delete(ctx, url, newRevision, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+}
+
+func TestStaleRevisionIsRejected(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+	fake.set("/bosk/node", `{"id":"node"}`)
+
+	client := NewBoskClientWithoutAuth(http.DefaultClient, BoskClientSettings{RevisionHeader: "ETag"})
+	url := testServer.URL + "/bosk/node"
+	var diags diag.Diagnostics
+	ctx := context.Background()
+
+	_, revision := client.GetJSONAsString(ctx, url, &diags)
+	fake.set("/bosk/node", `{"id":"node","changedBy":"someone else"}`)
+
+	client.PutJSONAsString(ctx, url, `{"id":"node"}`, revision, &diags)
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Node changed since last refresh" {
+		t.Errorf("expected stale PUT to fail with a concurrent change error; got %v", diags)
+	}
+	if value, _ := fake.get("/bosk/node"); value != `{"id":"node","changedBy":"someone else"}` {
+		t.Errorf("stale PUT should not have overwritten the node; got %s", value)
+	}
+
+	diags = nil
+	client.Delete(ctx, url, revision, &diags)
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Node changed since last refresh" {
+		t.Errorf("expected stale DELETE to fail with a concurrent change error; got %v", diags)
+	}
+}
+
+func TestMissingNode(t *testing.T) {
+	_, testServer := newFakeBosk(t)
+	defer testServer.Close()
+
+	client := NewBoskClientWithoutAuth(http.DefaultClient, BoskClientSettings{RevisionHeader: "ETag"})
+	url := testServer.URL + "/bosk/missing"
+	var diags diag.Diagnostics
+	ctx := context.Background()
+
+	contents := client.GetNode(ctx, url, &diags)
+	if diags.HasError() || contents.Found {
+		t.Errorf("expected missing node to be reported as not found without error; got %+v, %v", contents, diags)
+	}
+
+	client.GetJSONAsString(ctx, url, &diags)
+	if !diags.HasError() {
+		t.Errorf("expected GetJSONAsString to report an error for a missing node")
+	}
+
+	diags = nil
+	client.Delete(ctx, url, "", &diags)
+	if diags.HasError() {
+		t.Errorf("expected DELETE of missing node to succeed; got %v", diags)
+	}
+	client.Delete(ctx, url, `"123"`, &diags)
+	if diags.HasError() {
+		t.Errorf("expected conditional DELETE of missing node to succeed; got %v", diags)
+	}
+}
+
+func TestGetPreservesLargeNumbers(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+	fake.set("/bosk/node", `{ "id": "node", "counter": 18446744073709551617, "ratio": 0.1000000000000000055511151231257827 }`)
+
+	client := NewBoskClientWithoutAuth(http.DefaultClient, BoskClientSettings{})
+	var diags diag.Diagnostics
+	ctx := context.Background()
+
+	json, _ := client.GetJSONAsString(ctx, testServer.URL+"/bosk/node", &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	expected := `{"counter":18446744073709551617,"id":"node","ratio":0.1000000000000000055511151231257827}`
+	if json != expected {
+		t.Errorf("expected %s, got %s", expected, json)
+	}
+}
+
+// hangingServer never responds until the client gives up.
+func hangingServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server only notices a client disconnect once the request body has been consumed
+		_, _ = io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+}
+
+func TestRequestsAreCancellable(t *testing.T) {
+	server := hangingServer()
+	defer server.Close()
+
+	client := NewBoskClientWithoutAuth(http.DefaultClient, BoskClientSettings{})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var diags diag.Diagnostics
+
+	client.PutJSONAsString(ctx, server.URL+"/bosk/node", `{}`, "", &diags)
+	if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), "did not complete") {
+		t.Errorf("expected cancellation error; got %v", diags)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	server := hangingServer()
+	defer server.Close()
+
+	client := NewBoskClientWithoutAuth(&http.Client{Timeout: 50 * time.Millisecond}, BoskClientSettings{})
+	var diags diag.Diagnostics
+
+	client.GetNode(context.Background(), server.URL+"/bosk/node", &diags)
+	if !diags.HasError() {
+		t.Errorf("expected timeout error")
+	}
+}
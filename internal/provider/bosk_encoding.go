@@ -0,0 +1,330 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// entryURL returns the address of the entry with the given id within the container
+// (a catalog, listing or side table) at containerURL.
+func entryURL(containerURL string, id string) string {
+	return strings.TrimSuffix(containerURL, "/") + "/" + escapePathSegment(id)
+}
+
+// resolveEntryURL returns the address of a container given by either its url or path,
+// along with the address of the entry with the given id, which is unknown until both are known.
+// The container's attributes are named <container>_url and <container>_path.
+func resolveEntryURL(client *BoskClient, containerURL types.String, containerPath types.String, id types.String, container string, diag *diag.Diagnostics) (types.String, types.String) {
+	containerURL = resolveURL(client, containerURL, containerPath, path.Root(container+"_url"), path.Root(container+"_path"), diag)
+	if containerURL.IsNull() || containerURL.IsUnknown() || id.IsUnknown() {
+		return containerURL, types.StringUnknown()
+	}
+	return containerURL, types.StringValue(entryURL(containerURL.ValueString(), id.ValueString()))
+}
+
+// splitEntryAddress splits an import ID of the form <container>/<id> into its container address and entry id.
+func splitEntryAddress(address string) (string, string, error) {
+	slash := strings.LastIndex(address, "/")
+	if slash <= 0 || slash == len(address)-1 {
+		return "", "", fmt.Errorf("expected an address of the form <container>/<id>; got %q", address)
+	}
+	id, err := url.PathUnescape(address[slash+1:])
+	if err != nil {
+		return "", "", fmt.Errorf("invalid entry id in %q: %w", address, err)
+	}
+	return address[:slash], id, nil
+}
+
+// containerAddress interprets the container part of an import ID as either a url or a path.
+// Returns the values of the container's url and path attributes respectively.
+func containerAddress(container string) (types.String, types.String) {
+	if strings.HasPrefix(container, "http://") || strings.HasPrefix(container, "https://") {
+		return types.StringValue(container), types.StringNull()
+	}
+	return types.StringNull(), types.StringValue(container)
+}
+
+// checkEntryID returns a description of why the given JSON is not a bosk entity with the given id,
+// or "" if it is one.
+func checkEntryID(valueJSON string, id string) string {
+	var entity map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(valueJSON), &entity); err != nil {
+		return fmt.Sprintf("Expected a JSON object with an \"id\" field: %s", err)
+	}
+	rawID, ok := entity["id"]
+	if !ok {
+		return fmt.Sprintf("Expected a JSON object with an \"id\" field of %q", id)
+	}
+	var actual string
+	if err := json.Unmarshal(rawID, &actual); err != nil {
+		return fmt.Sprintf("Expected the \"id\" field to be a string. Got: %s", rawID)
+	}
+	if actual != id {
+		return fmt.Sprintf("Expected the \"id\" field to match the entry id %q. Got: %q", id, actual)
+	}
+	return ""
+}
+
+// catalogEntry is one element of bosk's Catalog encoding, in which each entry is a single-key object.
+type catalogEntry struct {
+	ID    string
+	Value interface{}
+}
+
+// decodeCatalog parses bosk's Catalog encoding, preserving the order of the entries.
+// Numbers are preserved losslessly, as by decodeJSONLosslessly.
+func decodeCatalog(document []byte) ([]catalogEntry, error) {
+	parsed, err := decodeJSONLosslessly(document)
+	if err != nil {
+		return nil, err
+	}
+	return catalogEntriesOf(parsed)
+}
+
+// catalogEntriesOf interprets an already-decoded JSON value as bosk's Catalog encoding.
+func catalogEntriesOf(parsed interface{}) ([]catalogEntry, error) {
+	elements, ok := parsed.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a catalog to be a JSON array")
+	}
+	entries := make([]catalogEntry, 0, len(elements))
+	seen := make(map[string]bool, len(elements))
+	for i, element := range elements {
+		object, ok := element.(map[string]interface{})
+		if !ok || len(object) != 1 {
+			return nil, fmt.Errorf("expected catalog element %v to be an object with a single field", i)
+		}
+		for id, value := range object {
+			if seen[id] {
+				return nil, fmt.Errorf("duplicate catalog entry %q", id)
+			}
+			seen[id] = true
+			entries = append(entries, catalogEntry{ID: id, Value: value})
+		}
+	}
+	return entries, nil
+}
+
+// catalogEntriesFromJSON decodes the given JSON-encoded values, returning them as
+// catalog entries in order of id, since Terraform maps have no other order.
+func catalogEntriesFromJSON(valuesJSON map[string]string) ([]catalogEntry, error) {
+	ids := make([]string, 0, len(valuesJSON))
+	for id := range valuesJSON {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	entries := make([]catalogEntry, 0, len(ids))
+	for _, id := range ids {
+		value, err := decodeJSONLosslessly([]byte(valuesJSON[id]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for entry %q: %w", id, err)
+		}
+		entries = append(entries, catalogEntry{ID: id, Value: value})
+	}
+	return entries, nil
+}
+
+// catalogElements returns the entries in bosk's Catalog encoding, ready for writeCanonicalJSON.
+func catalogElements(entries []catalogEntry) []interface{} {
+	elements := make([]interface{}, len(entries))
+	for i, entry := range entries {
+		elements[i] = map[string]interface{}{entry.ID: entry.Value}
+	}
+	return elements
+}
+
+// encodeCatalog returns bosk's Catalog encoding of the given entries.
+func encodeCatalog(entries []catalogEntry) (string, error) {
+	return encodeJSON(catalogElements(entries))
+}
+
+// encodeListing returns bosk's Listing encoding of the given ids within the given domain,
+// which should satisfy checkReference.
+func encodeListing(ids []string, domain string) (string, error) {
+	seen := make(map[string]bool, len(ids))
+	elements := make([]interface{}, len(ids))
+	for i, id := range ids {
+		if seen[id] {
+			return "", fmt.Errorf("duplicate listing id %q", id)
+		}
+		seen[id] = true
+		elements[i] = id
+	}
+	return encodeJSON(map[string]interface{}{
+		"ids":    elements,
+		"domain": domain,
+	})
+}
+
+// encodeSideTable returns bosk's SideTable encoding of the given entries within the given domain,
+// which should satisfy checkReference.
+func encodeSideTable(domain string, entries []catalogEntry) (string, error) {
+	return encodeJSON(map[string]interface{}{
+		"domain":     domain,
+		"valuesById": catalogElements(entries),
+	})
+}
+
+func encodeJSON(value interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := writeCanonicalJSON(&buf, value); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// referencePath returns the bosk path string for the given segments, as used in
+// the JSON encoding of a Reference.
+func referencePath(segments []string) (string, error) {
+	if len(segments) == 0 {
+		return "/", nil
+	}
+	var result strings.Builder
+	for i, segment := range segments {
+		if segment == "" {
+			return "", fmt.Errorf("segment %v is empty", i)
+		}
+		result.WriteString("/")
+		result.WriteString(escapePathSegment(segment))
+	}
+	return result.String(), nil
+}
+
+// escapePathSegment URL-encodes a segment of a bosk path as bosk expects,
+// including "+", which bosk would otherwise decode as a space.
+func escapePathSegment(segment string) string {
+	return strings.ReplaceAll(url.PathEscape(segment), "+", "%2B")
+}
+
+// checkReference returns a description of why the given string is not a bosk path,
+// or "" if it is one.
+func checkReference(reference string) string {
+	if !strings.HasPrefix(reference, "/") {
+		return fmt.Sprintf("expected a bosk path starting with \"/\"; got %q", reference)
+	}
+	return ""
+}
+
+// parseRelativePath splits a bosk path relative to some node, like "worlds/earth",
+// into its unescaped segments.
+func parseRelativePath(relative string) ([]string, error) {
+	trimmed := strings.Trim(relative, "/")
+	if trimmed == "" {
+		return nil, fmt.Errorf("expected a path below the node; got %q", relative)
+	}
+	segments := strings.Split(trimmed, "/")
+	for i, segment := range segments {
+		if segment == "" {
+			return nil, fmt.Errorf("segment %v of %q is empty", i, relative)
+		}
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, fmt.Errorf("invalid segment %q in %q: %w", segment, relative, err)
+		}
+		segments[i] = unescaped
+	}
+	return segments, nil
+}
+
+// descendantURL returns the address of the node at the given segments below the node at nodeURL.
+func descendantURL(nodeURL string, segments []string) string {
+	result := strings.TrimSuffix(nodeURL, "/")
+	for _, segment := range segments {
+		result += "/" + escapePathSegment(segment)
+	}
+	return result
+}
+
+// boskChild returns the named field of an object, or the entry with the given id in a catalog.
+func boskChild(node interface{}, name string) (interface{}, bool) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		child, ok := v[name]
+		return child, ok
+	case []interface{}:
+		entries, err := catalogEntriesOf(v)
+		if err != nil {
+			return nil, false
+		}
+		for _, entry := range entries {
+			if entry.ID == name {
+				return entry.Value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// withBoskChild returns node with its named field or catalog entry set to value, or removed if !present.
+// A new catalog entry goes at the end. Objects are modified in place; other values are returned unchanged.
+func withBoskChild(node interface{}, name string, value interface{}, present bool) interface{} {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if present {
+			v[name] = value
+		} else {
+			delete(v, name)
+		}
+		return v
+	case []interface{}:
+		entries, err := catalogEntriesOf(v)
+		if err != nil {
+			return v
+		}
+		found := false
+		result := make([]catalogEntry, 0, len(entries)+1)
+		for _, entry := range entries {
+			if entry.ID != name {
+				result = append(result, entry)
+			} else if present {
+				found = true
+				result = append(result, catalogEntry{ID: name, Value: value})
+			}
+		}
+		if present && !found {
+			result = append(result, catalogEntry{ID: name, Value: value})
+		}
+		return catalogElements(result)
+	}
+	return node
+}
+
+// spliceDescendant returns target with its descendant at the given segments replaced by source's,
+// or removed if source has none there (or sourcePresent is false).
+// If target has no parent node for that descendant, it is returned unchanged.
+func spliceDescendant(target interface{}, source interface{}, sourcePresent bool, segments []string) interface{} {
+	name := segments[0]
+	var sourceChild interface{}
+	inSource := false
+	if sourcePresent {
+		sourceChild, inSource = boskChild(source, name)
+	}
+	if len(segments) == 1 {
+		return withBoskChild(target, name, sourceChild, inSource)
+	}
+	targetChild, inTarget := boskChild(target, name)
+	if !inTarget {
+		return target
+	}
+	return withBoskChild(target, name, spliceDescendant(targetChild, sourceChild, inSource, segments[1:]), true)
+}
+
+// boskDescendant returns the node at the given segments below node.
+func boskDescendant(node interface{}, segments []string) (interface{}, bool) {
+	for _, segment := range segments {
+		child, ok := boskChild(node, segment)
+		if !ok {
+			return nil, false
+		}
+		node = child
+	}
+	return node, true
+}
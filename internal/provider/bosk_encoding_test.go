@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestEntryURL(t *testing.T) {
+	tests := []struct {
+		container string
+		id        string
+		expected  string
+	}{
+		{"http://localhost:1740/bosk/worlds", "world", "http://localhost:1740/bosk/worlds/world"},
+		{"http://localhost:1740/bosk/worlds/", "world", "http://localhost:1740/bosk/worlds/world"},
+		{"http://localhost:1740/bosk/worlds", "a b/c", "http://localhost:1740/bosk/worlds/a%20b%2Fc"},
+		{"http://localhost:1740/bosk/worlds", "c++", "http://localhost:1740/bosk/worlds/c%2B%2B"},
+	}
+	for _, test := range tests {
+		actual := entryURL(test.container, test.id)
+		if actual != test.expected {
+			t.Errorf("entryURL(%q, %q): expected %q, got %q", test.container, test.id, test.expected, actual)
+		}
+	}
+}
+
+func TestSplitEntryAddress(t *testing.T) {
+	container, id, err := splitEntryAddress("http://localhost:1740/bosk/worlds/a%20b%2Fc")
+	if err != nil || container != "http://localhost:1740/bosk/worlds" || id != "a b/c" {
+		t.Errorf("unexpected result: %q %q %v", container, id, err)
+	}
+	container, id, err = splitEntryAddress("worlds/world")
+	if err != nil || container != "worlds" || id != "world" {
+		t.Errorf("unexpected result: %q %q %v", container, id, err)
+	}
+	for _, invalid := range []string{"world", "/world", "worlds/", "worlds/%zz"} {
+		if _, _, err := splitEntryAddress(invalid); err == nil {
+			t.Errorf("%q: expected an error", invalid)
+		}
+	}
+}
+
+func TestCheckEntryID(t *testing.T) {
+	tests := []struct {
+		json     string
+		expected string
+	}{
+		{`{"id":"world","name":"Earth"}`, ``},
+		{`{"name":"Earth"}`, `"id" field`},
+		{`{"id":"mars"}`, `match the entry id`},
+		{`{"id":7}`, `to be a string`},
+		{`["world"]`, `JSON object`},
+	}
+	for _, test := range tests {
+		problem := checkEntryID(test.json, "world")
+		if test.expected == "" {
+			if problem != "" {
+				t.Errorf("%s: expected no problem; got %v", test.json, problem)
+			}
+		} else if !strings.Contains(problem, test.expected) {
+			t.Errorf("%s: expected problem containing %q; got %q", test.json, test.expected, problem)
+		}
+	}
+}
+
+func TestDecodeCatalog(t *testing.T) {
+	entries, err := decodeCatalog([]byte(`[{"b":{"id":"b"}},{"a":{"id":"a","n":12345678901234567890}}]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].ID != "b" || entries[1].ID != "a" {
+		t.Errorf("expected entries b, a in order; got %v", entries)
+	}
+	for _, invalid := range []string{`{}`, `[{"a":1,"b":2}]`, `[{}]`, `["a"]`, `[{"a":1},{"a":2}]`, `[`} {
+		if _, err := decodeCatalog([]byte(invalid)); err == nil {
+			t.Errorf("%s: expected an error", invalid)
+		}
+	}
+}
+
+func TestParseRelativePath(t *testing.T) {
+	tests := []struct {
+		relative string
+		expected string
+	}{
+		{"b", "[b]"},
+		{"/worlds/a%2Fb/", "[worlds a/b]"},
+		{"", "error"},
+		{"a//b", "error"},
+		{"a/%zz", "error"},
+	}
+	for _, test := range tests {
+		segments, err := parseRelativePath(test.relative)
+		actual := fmt.Sprint(segments)
+		if err != nil {
+			actual = "error"
+		}
+		if actual != test.expected {
+			t.Errorf("%q: expected %v; got %v", test.relative, test.expected, actual)
+		}
+	}
+}
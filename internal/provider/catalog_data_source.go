@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &CatalogDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &CatalogDataSource{}
+
+func NewCatalogDataSource() datasource.DataSource {
+	return &CatalogDataSource{}
+}
+
+// CatalogDataSource reads a bosk Catalog and presents its entries in a form suited to for_each.
+type CatalogDataSource struct {
+	client *BoskClient
+}
+
+type CatalogDataSourceModel struct {
+	URL          types.String        `tfsdk:"url"`
+	Path         types.String        `tfsdk:"path"`
+	Filter       *CatalogFilterModel `tfsdk:"filter"`
+	IDs          types.List          `tfsdk:"ids"`
+	Entries_json types.Map           `tfsdk:"entries_json"`
+	ETag         types.String        `tfsdk:"etag"`
+}
+
+type CatalogFilterModel struct {
+	Pointer    types.String `tfsdk:"pointer"`
+	Value_json JSONValue    `tfsdk:"value_json"`
+}
+
+func (d *CatalogDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_catalog"
+}
+
+func (d *CatalogDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The entries of a bosk Catalog. Bosk serializes a catalog as an array of single-key objects, like `[{\"world\":{\"id\":\"world\"}}]`; this data source presents them as a list of ids and a map of values, suitable for `for_each`.",
+
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The HTTP address of the catalog. Exactly one of `url` and `path` must be specified.",
+				Optional:            true,
+				Computed:            true,
+				Validators:          []validator.String{httpURLValidator{}},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The location of the catalog relative to the provider's `base_url`. Exactly one of `url` and `path` must be specified.",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "The ids of the catalog's entries, in catalog order.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"entries_json": schema.MapAttribute{
+				MarkdownDescription: "The JSON-encoded value of each of the catalog's entries, keyed by id.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"etag": schema.StringAttribute{
+				MarkdownDescription: "The revision of the catalog, as reported by the server. Null if the server doesn't report revisions.",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"filter": schema.SingleNestedBlock{
+				MarkdownDescription: "Restricts the results to entries containing a particular value.",
+				Attributes: map[string]schema.Attribute{
+					"pointer": schema.StringAttribute{
+						MarkdownDescription: "An [RFC 6901](https://www.rfc-editor.org/rfc/rfc6901) JSON Pointer, such as `/kind`, locating the value to compare within each entry. Entries in which it locates nothing are excluded.",
+						Optional:            true,
+					},
+					"value_json": schema.StringAttribute{
+						MarkdownDescription: "The JSON-encoded value an entry must have at `pointer` to be included. Compared as JSON, not as text.",
+						CustomType:          JSONType{},
+						Optional:            true,
+						Validators:          []validator.String{jsonValidator{}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CatalogDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BoskClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *BoskClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client.withErrorAttributes(errorAttributes{address: path.Root("url")})
+}
+
+func (d *CatalogDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data CatalogDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateAddress(data.URL, data.Path, path.Root("url"), path.Root("path"), &resp.Diagnostics)
+
+	if data.Filter != nil {
+		filterPath := path.Root("filter")
+		if data.Filter.Pointer.IsNull() {
+			resp.Diagnostics.AddAttributeError(filterPath.AtName("pointer"), "Missing required value", "Expected a value in the configuration")
+		} else if !data.Filter.Pointer.IsUnknown() {
+			if _, err := parseJSONPointer(data.Filter.Pointer.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(filterPath.AtName("pointer"), "Invalid JSON pointer", err.Error())
+			}
+		}
+		if data.Filter.Value_json.IsNull() {
+			resp.Diagnostics.AddAttributeError(filterPath.AtName("value_json"), "Missing required value", "Expected a value in the configuration")
+		}
+	}
+}
+
+func (d *CatalogDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		addUnconfiguredError(&resp.Diagnostics)
+		return
+	}
+
+	var data CatalogDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.URL = resolveURL(d.client, data.URL, data.Path, path.Root("url"), path.Root("path"), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	catalogJSON, revision := d.client.GetJSONAsString(ctx, data.URL.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if revision == "" {
+		data.ETag = types.StringNull()
+	} else {
+		data.ETag = types.StringValue(revision)
+	}
+
+	ids, entries := catalogContents(catalogJSON, data.Filter, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.IDs, diags = types.ListValue(types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	data.Entries_json, diags = types.MapValue(types.StringType, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "read bosk catalog datasource", map[string]interface{}{
+		"url":     data.URL.ValueString(),
+		"entries": len(ids),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// catalogContents returns the ids and canonical JSON values of the catalog's entries that pass the filter, if any.
+func catalogContents(catalogJSON string, filter *CatalogFilterModel, diags *diag.Diagnostics) ([]attr.Value, map[string]attr.Value) {
+	entries, err := decodeCatalog([]byte(catalogJSON))
+	if err != nil {
+		diags.AddAttributeError(path.Root("url"), "Node is not a catalog", err.Error())
+		return nil, nil
+	}
+
+	var filterValue interface{}
+	if filter != nil {
+		filterValue, err = decodeJSONLosslessly([]byte(filter.Value_json.ValueString()))
+		if err != nil {
+			diags.AddAttributeError(path.Root("filter").AtName("value_json"), "Invalid JSON", err.Error())
+			return nil, nil
+		}
+	}
+
+	ids := make([]attr.Value, 0, len(entries))
+	values := make(map[string]attr.Value, len(entries))
+	for _, entry := range entries {
+		if filter != nil {
+			selected, err := resolveJSONPointer(entry.Value, filter.Pointer.ValueString())
+			if err != nil || !jsonValuesEqual(selected, filterValue) {
+				continue
+			}
+		}
+		var buf bytes.Buffer
+		if err := writeCanonicalJSON(&buf, entry.Value); err != nil {
+			diags.AddError("Unable to encode catalog entry", fmt.Sprintf("Entry %q: %s", entry.ID, err))
+			return nil, nil
+		}
+		ids = append(ids, types.StringValue(entry.ID))
+		values[entry.ID] = types.StringValue(buf.String())
+	}
+	return ids, values
+}
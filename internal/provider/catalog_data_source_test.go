@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testCatalog = `[
+	{"mars": {"id": "mars", "kind": "planet", "moons": 2}},
+	{"earth": {"id": "earth", "kind": "planet", "moons": 1.0}},
+	{"luna": {"id": "luna", "kind": "moon"}},
+	{"ceres": {"id": "ceres"}}
+]`
+
+func TestCatalogContentsFilter(t *testing.T) {
+	tests := []struct {
+		filter   *CatalogFilterModel
+		expected []string
+	}{
+		{nil, []string{"mars", "earth", "luna", "ceres"}},
+		{&CatalogFilterModel{Pointer: types.StringValue("/kind"), Value_json: NewJSONValue(`"planet"`)}, []string{"mars", "earth"}},
+		{&CatalogFilterModel{Pointer: types.StringValue("/moons"), Value_json: NewJSONValue(`1`)}, []string{"earth"}},
+		{&CatalogFilterModel{Pointer: types.StringValue("/kind"), Value_json: NewJSONValue(`"comet"`)}, []string{}},
+	}
+	for _, test := range tests {
+		var diags diag.Diagnostics
+		ids, values := catalogContents(testCatalog, test.filter, &diags)
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		actual := make([]string, 0, len(ids))
+		for _, id := range ids {
+			actual = append(actual, id.(types.String).ValueString())
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(test.expected) {
+			t.Errorf("expected %v; got %v", test.expected, actual)
+		}
+		if len(values) != len(ids) {
+			t.Errorf("expected a value for each id; got %v", values)
+		}
+	}
+}
+
+func TestAccCatalogDataSource(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+	fake.set("/bosk/worlds", testCatalog)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "bosk" {
+						basic_auth_var_suffix = "NO_AUTH"
+						base_url              = "%s/bosk"
+					}
+					data "bosk_catalog" "all" {
+						path = "worlds"
+					}
+					data "bosk_catalog" "planets" {
+						path = "worlds"
+						filter {
+							pointer    = "/kind"
+							value_json = jsonencode("planet")
+						}
+					}
+				`, testServer.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bosk_catalog.all", "url", testServer.URL+"/bosk/worlds"),
+					resource.TestCheckResourceAttr("data.bosk_catalog.all", "ids.#", "4"),
+					resource.TestCheckResourceAttr("data.bosk_catalog.all", "ids.0", "mars"),
+					resource.TestCheckResourceAttr("data.bosk_catalog.all", "ids.3", "ceres"),
+					resource.TestCheckResourceAttr("data.bosk_catalog.all", "entries_json.luna", `{"id":"luna","kind":"moon"}`),
+					resource.TestCheckResourceAttrSet("data.bosk_catalog.all", "etag"),
+					resource.TestCheckResourceAttr("data.bosk_catalog.planets", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.bosk_catalog.planets", "entries_json.%", "2"),
+					resource.TestCheckResourceAttr("data.bosk_catalog.planets", "entries_json.earth", `{"id":"earth","kind":"planet","moons":1.0}`),
+				),
+			},
+		},
+	})
+}
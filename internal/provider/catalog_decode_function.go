@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &CatalogDecodeFunction{}
+
+func NewCatalogDecodeFunction() function.Function {
+	return &CatalogDecodeFunction{}
+}
+
+// CatalogDecodeFunction parses bosk's Catalog encoding into a map of entries.
+type CatalogDecodeFunction struct{}
+
+func (f *CatalogDecodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "catalog_decode"
+}
+
+func (f *CatalogDecodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Decodes a bosk Catalog",
+		MarkdownDescription: "Parses the JSON encoding bosk uses for a Catalog, returning the JSON-encoded value of each entry, keyed by id. The inverse of `catalog_encode`, except that a map does not preserve the order of the entries.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "catalog_json",
+				MarkdownDescription: "A JSON-encoded catalog, like `[{\"world\":{\"id\":\"world\"}}]`.",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *CatalogDecodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var catalogJSON string
+
+	resp.Error = req.Arguments.Get(ctx, &catalogJSON)
+	if resp.Error != nil {
+		return
+	}
+
+	entries, err := decodeCatalog([]byte(catalogJSON))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	result := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		result[entry.ID], err = encodeJSON(entry.Value)
+		if err != nil {
+			resp.Error = function.NewFuncError(err.Error())
+			return
+		}
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
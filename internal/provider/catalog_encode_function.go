@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &CatalogEncodeFunction{}
+
+func NewCatalogEncodeFunction() function.Function {
+	return &CatalogEncodeFunction{}
+}
+
+// CatalogEncodeFunction builds bosk's Catalog encoding from a map of entries.
+type CatalogEncodeFunction struct{}
+
+func (f *CatalogEncodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "catalog_encode"
+}
+
+func (f *CatalogEncodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Encodes a bosk Catalog",
+		MarkdownDescription: "Returns the JSON encoding bosk uses for a Catalog, an array of single-key objects like `[{\"world\":{\"id\":\"world\"}}]`. Entries appear in order of id, since Terraform maps have no other order.",
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name:                "entries_json",
+				MarkdownDescription: "The JSON-encoded value of each entry, keyed by id, like the `entries_json` attribute of the `bosk_catalog` data source.",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *CatalogEncodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var entriesJSON map[string]string
+
+	resp.Error = req.Arguments.Get(ctx, &entriesJSON)
+	if resp.Error != nil {
+		return
+	}
+
+	entries, err := catalogEntriesFromJSON(entriesJSON)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	result, err := encodeCatalog(entries)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NewCatalogEntryResource manages a single entry of a bosk Catalog,
+// leaving the catalog's other entries to be managed independently.
+func NewCatalogEntryResource() resource.Resource {
+	return &EntryResource{kind: catalogEntryKind}
+}
+
+// CatalogEntryModel is the EntryModel of a catalog entry.
+type CatalogEntryModel struct {
+	ContainerURL  types.String   `tfsdk:"catalog_url"`
+	ContainerPath types.String   `tfsdk:"catalog_path"`
+	ID            types.String   `tfsdk:"id"`
+	Value_json    JSONValue      `tfsdk:"value_json"`
+	URL           types.String   `tfsdk:"url"`
+	ETag          types.String   `tfsdk:"etag"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// A catalog entry is the entity itself, so its id must match the entry's.
+var catalogEntryKind = entryKind{
+	typeName:        "_catalog_entry",
+	container:       "catalog",
+	noun:            "catalog entry",
+	model:           func(m *EntryModel) interface{} { return (*CatalogEntryModel)(m) },
+	checkValue:      checkEntryID,
+	mismatchSummary: "Catalog entry id mismatch",
+
+	description:          "A single entry in a bosk Catalog. Bosk serializes a catalog as an array of single-key objects, like `[{\"world\":{\"id\":\"world\"}}]`; this resource owns just one of those entries, so different configurations can manage different entries of the same catalog.",
+	idDescription:        "The id of the entry within the catalog. Changing it replaces the entry.",
+	valueJSONDescription: "The JSON-encoded contents of the entry. Must be an object whose `id` field matches `id`.",
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccCatalogEntryResource(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+	fake.set("/bosk/worlds/other", `{"id":"other"}`)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCatalogEntryResourceConfig(testServer.URL+"/bosk/", "earth", "Earth"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bosk_catalog_entry.test", "catalog_url", testServer.URL+"/bosk/worlds"),
+					resource.TestCheckResourceAttr("bosk_catalog_entry.test", "url", testServer.URL+"/bosk/worlds/earth"),
+					resource.TestCheckResourceAttr("bosk_catalog_entry.test", "value_json", `{"id":"earth","name":"Earth"}`),
+					resource.TestCheckResourceAttrSet("bosk_catalog_entry.test", "etag"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bosk_catalog_entry.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     "worlds/earth",
+			},
+			// Update testing
+			{
+				Config: testAccCatalogEntryResourceConfig(testServer.URL+"/bosk/", "earth", "Terra"),
+				Check:  resource.TestCheckResourceAttr("bosk_catalog_entry.test", "value_json", `{"id":"earth","name":"Terra"}`),
+			},
+			// An id that drifted out of band is reported on refresh, and corrected by applying
+			{
+				PreConfig: func() {
+					fake.set("/bosk/worlds/earth", `{"id":"mars","name":"Terra"}`)
+				},
+				Config: testAccCatalogEntryResourceConfig(testServer.URL+"/bosk/", "earth", "Terra"),
+				Check: func(*terraform.State) error {
+					if actual, _ := fake.get("/bosk/worlds/earth"); !jsonEquivalent(actual, `{"id":"earth","name":"Terra"}`) {
+						return fmt.Errorf("expected the drifted id to be corrected; found %s", actual)
+					}
+					return nil
+				},
+			},
+			// A new id is a new entry
+			{
+				Config: testAccCatalogEntryResourceConfig(testServer.URL+"/bosk/", "mars", "Mars"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bosk_catalog_entry.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: func(*terraform.State) error {
+					if _, ok := fake.get("/bosk/worlds/earth"); ok {
+						return fmt.Errorf("old entry was left behind")
+					}
+					if _, ok := fake.get("/bosk/worlds/mars"); !ok {
+						return fmt.Errorf("new entry was not created")
+					}
+					return nil
+				},
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			if _, ok := fake.get("/bosk/worlds/mars"); ok {
+				return fmt.Errorf("entry was not deleted")
+			}
+			if _, ok := fake.get("/bosk/worlds/other"); !ok {
+				return fmt.Errorf("unmanaged entry was deleted")
+			}
+			return nil
+		},
+	})
+}
+
+func TestAccCatalogEntryResourceIDMismatch(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "bosk" {
+						basic_auth_var_suffix = "NO_AUTH"
+					}
+					resource "bosk_catalog_entry" "test" {
+						catalog_url = "http://localhost:1740/bosk/worlds"
+						id          = "earth"
+						value_json  = jsonencode({ id = "mars" })
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Catalog entry id mismatch`),
+			},
+		},
+	})
+}
+
+func testAccCatalogEntryResourceConfig(base, id, name string) string {
+	return fmt.Sprintf(`
+		provider "bosk" {
+			basic_auth_var_suffix = "NO_AUTH"
+			base_url              = "%s"
+		}
+		resource "bosk_catalog_entry" "test" {
+			catalog_path = "worlds"
+			id           = "%s"
+			value_json   = jsonencode({ id = "%s", name = "%s" })
+		}
+	`, base, id, id, name)
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// cliClientFlags are the command-line equivalents of the provider settings that
+// subcommands of the provider binary need to reach a bosk server.
+type cliClientFlags struct {
+	baseURL            string
+	basicAuthVarSuffix string
+}
+
+func (f *cliClientFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&f.baseURL, "base-url", os.Getenv("TF_BOSK_URL"), "the provider's base_url; defaults to the TF_BOSK_URL environment variable")
+	flags.StringVar(&f.basicAuthVarSuffix, "basic-auth-var-suffix", "", "as for the provider, names the TF_BOSK_USERNAME_* and TF_BOSK_PASSWORD_* environment variables holding credentials, or NO_AUTH")
+}
+
+// newClient builds a BoskClient the way the provider would for the equivalent configuration,
+// using the provider's defaults for everything the flags don't cover.
+func (f *cliClientFlags) newClient(diags *diag.Diagnostics) *BoskClient {
+	if f.baseURL == "" {
+		diags.AddError("Missing base URL", "Specify -base-url or set the TF_BOSK_URL environment variable")
+	} else if problem := checkHTTPURL(f.baseURL); problem != "" {
+		diags.AddError("Invalid base URL", problem)
+	}
+	if f.basicAuthVarSuffix == "" {
+		diags.AddError(
+			"Missing authentication settings",
+			"Specify -basic-auth-var-suffix. If you don't want to use authentication, specify -basic-auth-var-suffix=NO_AUTH.",
+		)
+	}
+	if diags.HasError() {
+		return nil
+	}
+
+	settings := BoskClientSettings{
+		BaseURL:        f.baseURL,
+		RevisionHeader: "ETag",
+		MaxRetries:     4,
+		RetryMinDelay:  time.Second,
+		RetryMaxDelay:  30 * time.Second,
+	}
+	httpClient := &http.Client{
+		Transport: newTransport(nil, diags),
+		Timeout:   time.Minute,
+	}
+	auth := basicAuthFromEnvironment(f.basicAuthVarSuffix, diags)
+	if diags.HasError() {
+		return nil
+	}
+	if auth == nil {
+		return NewBoskClientWithoutAuth(httpClient, settings)
+	}
+	return NewBoskClient(httpClient, settings, auth)
+}
+
+// diagnosticsError prints any warnings to stderr, and returns the errors, if any, as a single error.
+func diagnosticsError(diags diag.Diagnostics, stderr io.Writer) error {
+	for _, warning := range diags.Warnings() {
+		fmt.Fprintf(stderr, "Warning: %s: %s\n", warning.Summary(), warning.Detail())
+	}
+	if !diags.HasError() {
+		return nil
+	}
+	var messages []string
+	for _, e := range diags.Errors() {
+		messages = append(messages, fmt.Sprintf("%s: %s", e.Summary(), e.Detail()))
+	}
+	return fmt.Errorf("%s", strings.Join(messages, "\n"))
+}
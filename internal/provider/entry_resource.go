@@ -0,0 +1,435 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &EntryResource{}
+var _ resource.ResourceWithImportState = &EntryResource{}
+var _ resource.ResourceWithValidateConfig = &EntryResource{}
+var _ resource.ResourceWithModifyPlan = &EntryResource{}
+
+// EntryResource manages a single entry of a bosk container keyed by entity id,
+// leaving the container's other entries to be managed independently.
+// The containers differ only in their encoding, which is described by an entryKind.
+type EntryResource struct {
+	kind   entryKind
+	client *BoskClient
+}
+
+// entryKind describes one kind of container whose entries an EntryResource can manage.
+type entryKind struct {
+	// typeName is appended to the provider's type name
+	typeName string
+
+	// container names the container in attribute names, like "catalog" in "catalog_url"
+	container string
+
+	// noun names an entry in log messages
+	noun string
+
+	// model converts the shared model into one whose tfsdk tags match this kind's attribute names
+	model func(*EntryModel) interface{}
+
+	// checkValue returns a description of why the value can't be stored under the given id, or "" if it can.
+	// Nil if the encoding places no constraints on the value.
+	checkValue func(valueJSON string, id string) string
+
+	// mismatchSummary is the summary of the diagnostic reporting a problem found by checkValue
+	mismatchSummary string
+
+	description          string
+	idDescription        string
+	valueJSONDescription string
+}
+
+// EntryModel holds the attributes common to every entryKind.
+// Each kind has a model type with the same fields, differing only in the tfsdk tags of the container's attributes.
+type EntryModel struct {
+	ContainerURL  types.String
+	ContainerPath types.String
+	ID            types.String
+	Value_json    JSONValue
+	URL           types.String
+	ETag          types.String
+	Timeouts      timeouts.Value
+}
+
+func (r *EntryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.kind.typeName
+}
+
+func (r *EntryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	container := r.kind.container
+	noun := r.kind.containerNoun()
+	resp.Schema = schema.Schema{
+		MarkdownDescription: r.kind.description,
+
+		Attributes: map[string]schema.Attribute{
+			container + "_url": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The HTTP address of the %s. Exactly one of `%s_url` and `%s_path` must be specified.", noun, container, container),
+				Optional:            true,
+				Computed:            true,
+				Validators:          []validator.String{httpURLValidator{}},
+			},
+			container + "_path": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The location of the %s relative to the provider's `base_url`. Exactly one of `%s_url` and `%s_path` must be specified.", noun, container, container),
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: r.kind.idDescription,
+				Required:            true,
+			},
+			"value_json": schema.StringAttribute{
+				MarkdownDescription: r.kind.valueJSONDescription,
+				CustomType:          JSONType{},
+				Required:            true,
+				Validators:          []validator.String{jsonValidator{}},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The HTTP address of the entry, formed by appending `id` to the %s's address.", noun),
+				Computed:            true,
+			},
+			"etag": schema.StringAttribute{
+				MarkdownDescription: "The revision of the entry as last observed from the server, sent in `If-Match` to detect concurrent changes. Null if the server doesn't report revisions.",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *EntryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BoskClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *BoskClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withErrorAttributes(errorAttributes{value: path.Root("value_json")})
+}
+
+// containerNoun is the container's name as it appears in prose, like "side table".
+func (k entryKind) containerNoun() string {
+	noun := []byte(k.container)
+	for i, c := range noun {
+		if c == '_' {
+			noun[i] = ' '
+		}
+	}
+	return string(noun)
+}
+
+// ResolveURL fills in the container's url field from its path, and the url field from the container's url and id.
+func (m *EntryModel) ResolveURL(client *BoskClient, kind entryKind, diag *diag.Diagnostics) {
+	m.ContainerURL, m.URL = resolveEntryURL(client, m.ContainerURL, m.ContainerPath, m.ID, kind.container, diag)
+}
+
+// ValidateEntry checks that the value can be stored under the entry's id.
+// Unknown values are skipped.
+func (m *EntryModel) ValidateEntry(kind entryKind, diag *diag.Diagnostics) {
+	if kind.checkValue == nil || m.ID.IsUnknown() || m.Value_json.IsUnknown() || m.Value_json.IsNull() {
+		return
+	}
+	if problem := kind.checkValue(m.Value_json.ValueString(), m.ID.ValueString()); problem != "" {
+		diag.AddAttributeError(path.Root("value_json"), kind.mismatchSummary, problem)
+	}
+}
+
+func (m *EntryModel) url() string {
+	return m.URL.ValueString()
+}
+
+func (m *EntryModel) revision() string {
+	return m.ETag.ValueString()
+}
+
+// SetRevision records the revision reported by the server, if any.
+func (m *EntryModel) SetRevision(revision string) {
+	if revision == "" {
+		m.ETag = types.StringNull()
+	} else {
+		m.ETag = types.StringValue(revision)
+	}
+}
+
+func (r *EntryResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data EntryModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, r.kind.model(&data))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateAddress(data.ContainerURL, data.ContainerPath, path.Root(r.kind.container+"_url"), path.Root(r.kind.container+"_path"), &resp.Diagnostics)
+	data.ValidateEntry(r.kind, &resp.Diagnostics)
+}
+
+func (r *EntryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy, or if the provider has not been configured yet.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var data EntryModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, r.kind.model(&data))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Resolve the address now so the plan shows the actual url
+	data.ResolveURL(r.client, r.kind, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, r.kind.model(&data))...)
+	if !data.URL.IsUnknown() {
+		r.client.ClaimNode(ctx, resp.Private, data.url(), nil, path.Root("url"), &resp.Diagnostics)
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var state EntryModel
+	resp.Diagnostics.Append(req.State.Get(ctx, r.kind.model(&state))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A different id or container is a different entry
+	if !data.URL.Equal(state.URL) {
+		resp.RequiresReplace.Append(path.Root("url"))
+	}
+}
+
+func (r *EntryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		addUnconfiguredError(&resp.Diagnostics)
+		return
+	}
+
+	var data EntryModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, r.kind.model(&data))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	data.ResolveURL(r.client, r.kind, &resp.Diagnostics)
+	data.ValidateEntry(r.kind, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	revision := r.client.PutJSONAsString(ctx, data.url(), data.Value_json.ValueString(), "", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.SetRevision(revision)
+
+	tflog.Debug(ctx, "created bosk "+r.kind.noun, map[string]interface{}{
+		"url": data.url(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, r.kind.model(&data))...)
+}
+
+func (r *EntryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// While the provider defers its configuration, keep the prior state
+	if r.client == nil {
+		return
+	}
+
+	var data EntryModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, r.kind.model(&data))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	contents := r.client.GetNode(ctx, data.url(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !contents.Found {
+		// Deleted out of band; let Terraform plan to re-create it
+		tflog.Info(ctx, "bosk "+r.kind.noun+" not found; removing from state", map[string]interface{}{
+			"url": data.url(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Value_json = NewJSONValue(contents.JSON)
+	data.SetRevision(contents.Revision)
+
+	// A mismatch that arose out of band shows up in the plan as a change to value_json, which applying will correct.
+	// An error here would prevent that, by failing the refresh.
+	if r.kind.checkValue != nil {
+		if problem := r.kind.checkValue(contents.JSON, data.ID.ValueString()); problem != "" {
+			resp.Diagnostics.AddAttributeWarning(path.Root("value_json"), r.kind.mismatchSummary, problem)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, r.kind.model(&data))...)
+}
+
+func (r *EntryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client == nil {
+		addUnconfiguredError(&resp.Diagnostics)
+		return
+	}
+
+	var data EntryModel
+	var state EntryModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, r.kind.model(&data))...)
+	resp.Diagnostics.Append(req.State.Get(ctx, r.kind.model(&state))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	data.ResolveURL(r.client, r.kind, &resp.Diagnostics)
+	data.ValidateEntry(r.kind, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ModifyPlan requires replacement if the url changes, so this is the entry we last read
+	revision := r.client.PutJSONAsString(ctx, data.url(), data.Value_json.ValueString(), state.revision(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.SetRevision(revision)
+
+	tflog.Debug(ctx, "updated bosk "+r.kind.noun, map[string]interface{}{
+		"url": data.url(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, r.kind.model(&data))...)
+}
+
+func (r *EntryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		addUnconfiguredError(&resp.Diagnostics)
+		return
+	}
+
+	var data EntryModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, r.kind.model(&data))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	r.client.Delete(ctx, data.url(), data.revision(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "deleted bosk "+r.kind.noun, map[string]interface{}{
+		"url": data.url(),
+	})
+}
+
+// ImportState accepts <container>/<id>, where <container> is either an absolute url,
+// or a path relative to the provider's base_url.
+func (r *EntryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.client == nil {
+		addUnconfiguredError(&resp.Diagnostics)
+		return
+	}
+
+	container, id, err := splitEntryAddress(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	data := EntryModel{
+		ID:       types.StringValue(id),
+		Timeouts: nullTimeouts(),
+	}
+	data.ContainerURL, data.ContainerPath = containerAddress(container)
+	data.ResolveURL(r.client, r.kind, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result_json, revision := r.client.GetJSONAsString(ctx, data.url(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Value_json = NewJSONValue(result_json)
+	data.SetRevision(revision)
+	data.ValidateEntry(r.kind, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "imported bosk "+r.kind.noun, map[string]interface{}{
+		"url": data.url(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, r.kind.model(&data))...)
+}
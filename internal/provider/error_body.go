@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// maxErrorBodyBytes bounds how much of an error response is read, so a misbehaving
+// server can't flood the diagnostics.
+const maxErrorBodyBytes = 64 * 1024
+
+// maxErrorDetailLength bounds how much of a non-JSON error body appears in a diagnostic.
+const maxErrorDetailLength = 2000
+
+// errorBody is the union of the fields of the JSON error formats we recognize:
+// Spring Boot's default error attributes, and RFC 7807 problem details.
+type errorBody struct {
+	Message string `json:"message"`
+	Error   string `json:"error"`
+	Path    string `json:"path"`
+	Title   string `json:"title"`
+	Detail  string `json:"detail"`
+}
+
+// readErrorDetail reads a bounded amount of an unsuccessful response's body
+// and returns a human-readable explanation, or "" if the body has nothing useful.
+func readErrorDetail(httpResp *http.Response) string {
+	bytes, err := io.ReadAll(io.LimitReader(httpResp.Body, maxErrorBodyBytes))
+	if err != nil || len(bytes) == 0 {
+		return ""
+	}
+
+	var parsed errorBody
+	if json.Unmarshal(bytes, &parsed) == nil {
+		var parts []string
+		for _, part := range []string{parsed.Error, parsed.Title, parsed.Message, parsed.Detail} {
+			if part != "" && !containsString(parts, part) {
+				parts = append(parts, part)
+			}
+		}
+		if len(parts) > 0 {
+			detail := strings.Join(parts, ": ")
+			if parsed.Path != "" {
+				detail += fmt.Sprintf(" (path %v)", parsed.Path)
+			}
+			return detail
+		}
+	}
+
+	if !utf8.Valid(bytes) {
+		return ""
+	}
+	text := strings.TrimSpace(string(bytes))
+	if len(text) > maxErrorDetailLength {
+		text = strings.ToValidUTF8(text[:maxErrorDetailLength], "") + "..."
+	}
+	return text
+}
+
+// errorAttributes names the attributes of a resource or data source that diagnostics about its requests should point at.
+// An empty path means there's no suitable attribute, and the diagnostic is reported without one.
+type errorAttributes struct {
+	// value holds the contents written to the node.
+	value path.Path
+
+	// address identifies the node's location.
+	address path.Path
+}
+
+// errorAttribute returns the attribute most likely responsible for an unsuccessful response,
+// or false if the cause isn't clear or there's no such attribute.
+func (a errorAttributes) errorAttribute(method string, statusCode int) (path.Path, bool) {
+	var result path.Path
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		if method == "PUT" || method == "PATCH" {
+			// The server couldn't accept the node's contents
+			result = a.value
+		}
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		// Either there's no such node, or its parent doesn't exist
+		result = a.address
+	}
+	return result, len(result.Steps()) > 0
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func responseWithBody(body string) *http.Response {
+	return &http.Response{Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func TestReadErrorDetail(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"empty", ``, ``},
+		{"spring", `{"timestamp":"2023-06-01T00:00:00.000+00:00","status":400,"error":"Bad Request","message":"Unknown field \"colour\"","path":"/bosk/widgets/w1"}`, `Bad Request: Unknown field "colour" (path /bosk/widgets/w1)`},
+		{"spring without message", `{"status":500,"error":"Internal Server Error","message":"","path":"/bosk"}`, `Internal Server Error (path /bosk)`},
+		{"problem details", `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Reference is not valid"}`, `Bad Request: Reference is not valid`},
+		{"duplicate fields", `{"error":"Bad Request","message":"Bad Request"}`, `Bad Request`},
+		{"unrecognized JSON", `{"reason":"nope"}`, `{"reason":"nope"}`},
+		{"text", "  Node does not exist\n", `Node does not exist`},
+		{"binary", "\xff\xfe\x00", ``},
+	}
+	for _, test := range tests {
+		actual := readErrorDetail(responseWithBody(test.body))
+		if actual != test.expected {
+			t.Errorf("%v: expected %q, got %q", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestReadErrorDetailIsBounded(t *testing.T) {
+	actual := readErrorDetail(responseWithBody(strings.Repeat("x", 10*maxErrorBodyBytes)))
+	if len(actual) > maxErrorDetailLength+len("...") {
+		t.Errorf("expected error detail to be truncated; got %v bytes", len(actual))
+	}
+}
+
+func TestErrorBodyInDiagnostics(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "PUT":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"status":400,"error":"Bad Request","message":"Unknown field \"colour\"","path":"/bosk/widget"}`)
+		case "DELETE":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = io.WriteString(w, `{"status":500,"error":"Internal Server Error","path":"/bosk/widget"}`)
+		}
+	}))
+	defer testServer.Close()
+
+	client := NewBoskClientWithoutAuth(http.DefaultClient, BoskClientSettings{}).withErrorAttributes(errorAttributes{
+		value:   path.Root("value_json"),
+		address: path.Root("url"),
+	})
+	url := testServer.URL + "/bosk/widget"
+	ctx := context.Background()
+
+	var diags diag.Diagnostics
+	client.PutJSONAsString(ctx, url, `{"colour":"red"}`, "", &diags)
+	expectAttributeError(t, diags, path.Root("value_json"), `Unknown field "colour"`)
+
+	diags = nil
+	client.Delete(ctx, url, "", &diags)
+	expectAttributeError(t, diags, path.Root("url"), "DELETE "+url+" returned unexpected status 405")
+
+	diags = nil
+	client.GetNode(ctx, url, &diags)
+	if !diags.HasError() {
+		t.Fatalf("expected an error")
+	}
+	if _, ok := diags.Errors()[0].(diag.DiagnosticWithPath); ok {
+		t.Errorf("expected a server error not to be attributed to any attribute")
+	}
+	if !strings.Contains(diags.Errors()[0].Detail(), "Internal Server Error") {
+		t.Errorf("expected the error to include the server's explanation; got %v", diags.Errors()[0].Detail())
+	}
+}
+
+func TestErrorsWithoutAttributes(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer testServer.Close()
+
+	// Like a listing member, which has neither value_json nor url
+	client := NewBoskClientWithoutAuth(http.DefaultClient, BoskClientSettings{}).withErrorAttributes(errorAttributes{})
+	url := testServer.URL + "/bosk/listing/member"
+	ctx := context.Background()
+
+	var putDiags, getDiags diag.Diagnostics
+	client.PutJSONAsString(ctx, url, `{}`, "", &putDiags)
+	client.GetJSONAsString(ctx, url, &getDiags)
+	for _, diags := range []diag.Diagnostics{putDiags, getDiags} {
+		if !diags.HasError() {
+			t.Fatalf("expected an error")
+		}
+		if _, ok := diags.Errors()[0].(diag.DiagnosticWithPath); ok {
+			t.Errorf("expected no attribute for %q", diags.Errors()[0].Detail())
+		}
+	}
+}
+
+func expectAttributeError(t *testing.T, diags diag.Diagnostics, expectedPath path.Path, expectedDetail string) {
+	t.Helper()
+	if !diags.HasError() {
+		t.Fatalf("expected an error")
+	}
+	withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(expectedPath) {
+		t.Errorf("expected an error on %v; got %v", expectedPath, diags)
+	}
+	if !strings.Contains(diags.Errors()[0].Detail(), expectedDetail) {
+		t.Errorf("expected the error to contain %q; got %q", expectedDetail, diags.Errors()[0].Detail())
+	}
+}
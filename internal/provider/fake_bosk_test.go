@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeBosk is a minimal stand-in for a bosk service endpoint.
+// It stores the most recently PUT body for each URL path,
+// and reports a revision in the ETag header that changes with every write.
+// PATCH is rejected with 405 unless supportsMergePatch is set.
+// Unless nestedRevisions is set, writing a node doesn't change the revisions of the nodes containing it.
+type fakeBosk struct {
+	t         *testing.T
+	mutex     sync.Mutex
+	nodes     map[string]string
+	revisions map[string]int
+	counter   int
+
+	supportsMergePatch bool
+	patches            []string
+	nestedRevisions    bool
+}
+
+func newFakeBosk(t *testing.T) (*fakeBosk, *httptest.Server) {
+	fake := newFakeBoskHandler(t)
+	return fake, httptest.NewServer(fake)
+}
+
+// newFakeBoskHandler returns a fakeBosk without a server, for tests that wrap it in another handler.
+func newFakeBoskHandler(t *testing.T) *fakeBosk {
+	return &fakeBosk{
+		t:         t,
+		nodes:     map[string]string{},
+		revisions: map[string]int{},
+	}
+}
+
+func (f *fakeBosk) get(path string) (string, bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	value, ok := f.nodes[path]
+	return value, ok
+}
+
+func (f *fakeBosk) set(path string, value string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.nodes[path] = value
+	f.bumpRevision(path)
+}
+
+func (f *fakeBosk) remove(path string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	delete(f.nodes, path)
+	delete(f.revisions, path)
+}
+
+// enableMergePatch makes the fake accept RFC 7396 merge patches.
+func (f *fakeBosk) enableMergePatch() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.supportsMergePatch = true
+}
+
+// enableNestedRevisions makes every write also change the revisions of the nodes containing it, as bosk does.
+func (f *fakeBosk) enableNestedRevisions() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.nestedRevisions = true
+}
+
+// patchesReceived returns the bodies of all PATCH requests, whether or not they were accepted.
+func (f *fakeBosk) patchesReceived() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]string(nil), f.patches...)
+}
+
+func (f *fakeBosk) bumpRevision(path string) {
+	f.counter++
+	f.revisions[path] = f.counter
+	f.bumpAncestors(path)
+}
+
+func (f *fakeBosk) bumpAncestors(path string) {
+	if !f.nestedRevisions {
+		return
+	}
+	for i := strings.LastIndex(path, "/"); i > 0; i = strings.LastIndex(path[:i], "/") {
+		if _, ok := f.nodes[path[:i]]; ok {
+			f.counter++
+			f.revisions[path[:i]] = f.counter
+		}
+	}
+}
+
+func (f *fakeBosk) etag(path string) string {
+	return fmt.Sprintf("\"%d\"", f.revisions[path])
+}
+
+// preconditionFailed checks If-Match against the node's current revision.
+func (f *fakeBosk) preconditionFailed(r *http.Request) bool {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		return false
+	}
+	_, exists := f.nodes[r.URL.Path]
+	return !exists || ifMatch != f.etag(r.URL.Path)
+}
+
+func (f *fakeBosk) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	path := r.URL.Path
+	switch r.Method {
+	case "GET":
+		value, ok := f.nodes[path]
+		if !ok {
+			f.t.Logf("GET %s returning 404", path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", f.etag(path))
+		if _, err := w.Write([]byte(value)); err != nil {
+			f.t.Errorf("error writing body: %s", err)
+		}
+	case "PUT":
+		if f.preconditionFailed(r) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			f.t.Errorf("error reading body: %s", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.t.Logf("PUT %s body %s", path, body)
+		f.nodes[path] = string(body)
+		f.bumpRevision(path)
+		w.Header().Set("ETag", f.etag(path))
+	case "PATCH":
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			f.t.Errorf("error reading body: %s", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.t.Logf("PATCH %s body %s", path, body)
+		f.patches = append(f.patches, string(body))
+		if !f.supportsMergePatch {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if contentType := r.Header.Get("Content-Type"); contentType != mergePatchContentType {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		if f.preconditionFailed(r) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		current, err := decodeJSONLosslessly([]byte(f.nodes[path]))
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		patch, err := decodeJSONLosslessly(body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		patched, err := encodeJSON(applyMergePatch(current, patch))
+		if err != nil {
+			f.t.Errorf("error encoding patched value: %s", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		f.nodes[path] = patched
+		f.bumpRevision(path)
+		w.Header().Set("ETag", f.etag(path))
+	case "DELETE":
+		if f.preconditionFailed(r) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		delete(f.nodes, path)
+		delete(f.revisions, path)
+		f.bumpAncestors(path)
+	default:
+		f.t.Errorf("unexpected method: %s", r.Method)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// runFunction calls the given function with the given arguments, returning its result and error, if any.
+// The result is initialized to nullResult, which determines its type.
+func runFunction(t *testing.T, f function.Function, nullResult attr.Value, arguments ...attr.Value) (attr.Value, string) {
+	t.Helper()
+	ctx := context.Background()
+	resp := function.RunResponse{
+		Result: function.NewResultData(nullResult),
+	}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(arguments)}, &resp)
+	if resp.Error != nil {
+		return nil, resp.Error.Error()
+	}
+	return resp.Result.Value(), ""
+}
+
+func stringMap(t *testing.T, elements map[string]string) types.Map {
+	t.Helper()
+	values := make(map[string]attr.Value, len(elements))
+	for k, v := range elements {
+		values[k] = types.StringValue(v)
+	}
+	result, diags := types.MapValue(types.StringType, values)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	return result
+}
+
+func stringList(elements ...string) types.List {
+	values := make([]attr.Value, len(elements))
+	for i, element := range elements {
+		values[i] = types.StringValue(element)
+	}
+	return types.ListValueMust(types.StringType, values)
+}
+
+func stringTuple(elements ...string) types.Tuple {
+	values := make([]attr.Value, len(elements))
+	elementTypes := make([]attr.Type, len(elements))
+	for i, element := range elements {
+		values[i] = types.StringValue(element)
+		elementTypes[i] = types.StringType
+	}
+	return types.TupleValueMust(elementTypes, values)
+}
+
+func expectFunctionResult(t *testing.T, description string, actual attr.Value, problem string, expected string) {
+	t.Helper()
+	if problem != "" {
+		t.Errorf("%s: unexpected error: %v", description, problem)
+	} else if actual.(types.String).ValueString() != expected {
+		t.Errorf("%s: expected %v; got %v", description, expected, actual)
+	}
+}
+
+func expectFunctionError(t *testing.T, description string, problem string, expected string) {
+	t.Helper()
+	if !strings.Contains(problem, expected) {
+		t.Errorf("%s: expected error containing %q; got %q", description, expected, problem)
+	}
+}
+
+func TestCatalogEncodeFunction(t *testing.T) {
+	f := NewCatalogEncodeFunction()
+	result, problem := runFunction(t, f, types.StringNull(), stringMap(t, map[string]string{
+		"mars":  `{"id": "mars", "moons": 2}`,
+		"earth": `{"id": "earth", "moons": 1.0}`,
+	}))
+	expectFunctionResult(t, "two entries", result, problem, `[{"earth":{"id":"earth","moons":1.0}},{"mars":{"id":"mars","moons":2}}]`)
+
+	result, problem = runFunction(t, f, types.StringNull(), stringMap(t, map[string]string{}))
+	expectFunctionResult(t, "empty", result, problem, `[]`)
+
+	_, problem = runFunction(t, f, types.StringNull(), stringMap(t, map[string]string{"earth": `{`}))
+	expectFunctionError(t, "invalid JSON", problem, `"earth"`)
+}
+
+func TestCatalogDecodeFunction(t *testing.T) {
+	f := NewCatalogDecodeFunction()
+	result, problem := runFunction(t, f, types.MapNull(types.StringType), types.StringValue(`[{"earth": {"id": "earth"}}, {"mars": {"id": "mars"}}]`))
+	if problem != "" {
+		t.Fatalf("unexpected error: %v", problem)
+	}
+	expected := stringMap(t, map[string]string{
+		"earth": `{"id":"earth"}`,
+		"mars":  `{"id":"mars"}`,
+	})
+	if !result.Equal(expected) {
+		t.Errorf("expected %v; got %v", expected, result)
+	}
+
+	_, problem = runFunction(t, f, types.MapNull(types.StringType), types.StringValue(`{"earth": {"id": "earth"}}`))
+	expectFunctionError(t, "not an array", problem, `JSON array`)
+}
+
+func TestListingEncodeFunction(t *testing.T) {
+	f := NewListingEncodeFunction()
+	result, problem := runFunction(t, f, types.StringNull(), stringList("mars", "earth"), types.StringValue("/worlds"))
+	expectFunctionResult(t, "two ids", result, problem, `{"domain":"/worlds","ids":["mars","earth"]}`)
+
+	_, problem = runFunction(t, f, types.StringNull(), stringList("mars", "mars"), types.StringValue("/worlds"))
+	expectFunctionError(t, "duplicate", problem, `duplicate listing id "mars"`)
+
+	_, problem = runFunction(t, f, types.StringNull(), stringList("mars"), types.StringValue("worlds"))
+	expectFunctionError(t, "relative domain", problem, `starting with "/"`)
+}
+
+func TestSideTableEncodeFunction(t *testing.T) {
+	f := NewSideTableEncodeFunction()
+	result, problem := runFunction(t, f, types.StringNull(), types.StringValue("/worlds"), stringMap(t, map[string]string{
+		"mars":  `"red"`,
+		"earth": `"blue"`,
+	}))
+	expectFunctionResult(t, "two entries", result, problem, `{"domain":"/worlds","valuesById":[{"earth":"blue"},{"mars":"red"}]}`)
+
+	_, problem = runFunction(t, f, types.StringNull(), types.StringValue("/worlds"), stringMap(t, map[string]string{"earth": `blue`}))
+	expectFunctionError(t, "invalid JSON", problem, `"earth"`)
+}
+
+func TestReferenceFunction(t *testing.T) {
+	tests := []struct {
+		segments []string
+		expected string
+	}{
+		{nil, "/"},
+		{[]string{"worlds", "earth"}, "/worlds/earth"},
+		{[]string{"worlds", "a/b c+d%"}, "/worlds/a%2Fb%20c%2Bd%25"},
+		{[]string{"worlds", "é"}, "/worlds/%C3%A9"},
+	}
+	f := NewReferenceFunction()
+	for _, test := range tests {
+		result, problem := runFunction(t, f, types.StringNull(), stringTuple(test.segments...))
+		expectFunctionResult(t, strings.Join(test.segments, ","), result, problem, test.expected)
+	}
+
+	_, problem := runFunction(t, f, types.StringNull(), stringTuple("worlds", ""))
+	expectFunctionError(t, "empty segment", problem, `segment 1 is empty`)
+}
+
+func TestAccFunctions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0"))),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "catalog" {
+						value = provider::bosk::catalog_encode({ earth = jsonencode({ id = "earth" }) })
+					}
+					output "decoded" {
+						value = provider::bosk::catalog_decode("[{\"earth\":{\"id\":\"earth\"}}]")["earth"]
+					}
+					output "listing" {
+						value = provider::bosk::listing_encode(["earth"], provider::bosk::reference("worlds"))
+					}
+					output "side_table" {
+						value = provider::bosk::side_table_encode("/worlds", { earth = jsonencode("blue") })
+					}
+					output "reference" {
+						value = provider::bosk::reference("worlds", "a/b")
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("catalog", `[{"earth":{"id":"earth"}}]`),
+					resource.TestCheckOutput("decoded", `{"id":"earth"}`),
+					resource.TestCheckOutput("listing", `{"domain":"/worlds","ids":["earth"]}`),
+					resource.TestCheckOutput("side_table", `{"domain":"/worlds","valuesById":[{"earth":"blue"}]}`),
+					resource.TestCheckOutput("reference", `/worlds/a%2Fb`),
+				),
+			},
+			{
+				Config: `
+					output "reference" {
+						value = provider::bosk::reference("worlds", "")
+					}
+				`,
+				ExpectError: regexp.MustCompile(`segment 1 is empty`),
+			},
+		},
+	})
+}
@@ -0,0 +1,325 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const (
+	generatedResourcesFile = "bosk_generated.tf"
+	generatedImportsFile   = "bosk_imports.tf"
+)
+
+// Generate implements the "generate" subcommand of the provider binary.
+// It reads a bosk subtree and writes Terraform configuration that manages it,
+// along with import blocks that bring the existing nodes under management.
+//
+// Objects are split into one resource per field, down to the given depth;
+// catalogs become one bosk_catalog_entry per entry; anything else, including
+// listings and side tables, becomes a single bosk_node.
+func Generate(ctx context.Context, args []string, stderr io.Writer) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var clientFlags cliClientFlags
+	clientFlags.register(flags)
+	rootPath := flags.String("path", "/", "the bosk path of the subtree to generate, relative to the base URL")
+	depth := flags.Int("depth", 1, "how many levels of objects and catalogs to split into separate resources; 0 generates a single bosk_node")
+	outDir := flags.String("out", ".", "the directory in which to write "+generatedResourcesFile+" and "+generatedImportsFile)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+	if *depth < 0 {
+		return fmt.Errorf("expected -depth to be zero or more; got %v", *depth)
+	}
+
+	var diags diag.Diagnostics
+	client := clientFlags.newClient(&diags)
+	if err := diagnosticsError(diags, stderr); err != nil {
+		return err
+	}
+
+	root := normalizePath(*rootPath)
+	valueJSON, _ := client.GetJSONAsString(ctx, client.URLForPath(root), &diags)
+	if err := diagnosticsError(diags, stderr); err != nil {
+		return err
+	}
+	value, err := decodeJSONLosslessly([]byte(valueJSON))
+	if err != nil {
+		return fmt.Errorf("invalid JSON at %v: %w", root, err)
+	}
+
+	g := generator{names: map[string]bool{}}
+	g.walk(root, value, *depth)
+
+	header := fmt.Sprintf("# Generated by terraform-provider-bosk from %s\n", client.URLForPath(root))
+	if err := writeNewFile(filepath.Join(*outDir, generatedResourcesFile), header+g.resourcesHCL()); err != nil {
+		return err
+	}
+	if err := writeNewFile(filepath.Join(*outDir, generatedImportsFile), header+g.importsHCL()); err != nil {
+		return err
+	}
+	fmt.Fprintf(stderr, "Generated %v resources in %s\n", len(g.resources), *outDir)
+	return nil
+}
+
+// normalizePath returns the given bosk path with a leading slash and no trailing slash, except for the root "/".
+func normalizePath(p string) string {
+	return "/" + strings.Trim(p, "/")
+}
+
+// childPath returns the bosk path of the given field or entry within the node at parent.
+func childPath(parent string, name string) string {
+	return strings.TrimSuffix(parent, "/") + "/" + escapePathSegment(name)
+}
+
+func writeNewFile(name string, contents string) error {
+	file, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("unable to create output file; remove any previously generated files first: %w", err)
+	}
+	if _, err := file.WriteString(contents); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+type generatedResource struct {
+	resourceType string
+	name         string
+	path         string
+	attributes   []hclAttribute
+}
+
+type hclAttribute struct {
+	name       string
+	expression string
+}
+
+type generator struct {
+	resources []generatedResource
+	names     map[string]bool
+}
+
+func (g *generator) walk(nodePath string, value interface{}, depth int) {
+	if depth > 0 {
+		if entries, ok := entityCatalogEntriesOf(value); ok && len(entries) > 0 {
+			for _, entry := range entries {
+				g.add("bosk_catalog_entry", childPath(nodePath, entry.ID), []hclAttribute{
+					{"catalog_path", hclString(nodePath)},
+					{"id", hclString(entry.ID)},
+					{"value_json", hclJSONEncode(entry.Value)},
+				})
+			}
+			return
+		}
+		if object, ok := value.(map[string]interface{}); ok && len(object) > 0 && !isListingOrSideTable(object) {
+			for _, name := range sortedKeys(object) {
+				g.walk(childPath(nodePath, name), object[name], depth-1)
+			}
+			return
+		}
+	}
+	g.add("bosk_node", nodePath, []hclAttribute{
+		{"path", hclString(nodePath)},
+		{"value_json", hclJSONEncode(value)},
+	})
+}
+
+// add records a resource for the node at nodePath. The path also serves as the import ID,
+// since a catalog entry's path is the <catalog>/<id> form its import expects.
+func (g *generator) add(resourceType string, nodePath string, attributes []hclAttribute) {
+	g.resources = append(g.resources, generatedResource{
+		resourceType: resourceType,
+		name:         g.uniqueName(nodePath),
+		path:         nodePath,
+		attributes:   attributes,
+	})
+}
+
+var nonIdentifierCharacters = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// uniqueName derives a Terraform resource name from a bosk path.
+func (g *generator) uniqueName(nodePath string) string {
+	base := strings.Trim(nonIdentifierCharacters.ReplaceAllString(nodePath, "_"), "_")
+	if base == "" {
+		base = "root"
+	} else if base[0] >= '0' && base[0] <= '9' {
+		base = "_" + base
+	}
+	name := base
+	for i := 2; g.names[name]; i++ {
+		name = fmt.Sprintf("%s_%v", base, i)
+	}
+	g.names[name] = true
+	return name
+}
+
+func (g *generator) resourcesHCL() string {
+	var buf strings.Builder
+	for _, r := range g.resources {
+		fmt.Fprintf(&buf, "\nresource %q %q {\n", r.resourceType, r.name)
+		writeHCLAttributes(&buf, r.attributes, "  ")
+		buf.WriteString("}\n")
+	}
+	return buf.String()
+}
+
+func (g *generator) importsHCL() string {
+	var buf strings.Builder
+	for _, r := range g.resources {
+		buf.WriteString("\nimport {\n")
+		writeHCLAttributes(&buf, []hclAttribute{
+			{"to", r.resourceType + "." + r.name},
+			{"id", hclString(r.path)},
+		}, "  ")
+		buf.WriteString("}\n")
+	}
+	return buf.String()
+}
+
+// isListingOrSideTable reports whether the given object is bosk's encoding of a Listing or SideTable,
+// whose fields can't be managed separately.
+func isListingOrSideTable(object map[string]interface{}) bool {
+	if _, ok := object["domain"]; !ok || len(object) != 2 {
+		return false
+	}
+	_, hasIDs := object["ids"]
+	_, hasValues := object["valuesById"]
+	return hasIDs || hasValues
+}
+
+// entityCatalogEntriesOf returns the entries of value if it is a catalog of entities,
+// each an object whose "id" field matches its key. This distinguishes catalogs from other
+// arrays of single-field objects, which are managed as a single node.
+func entityCatalogEntriesOf(value interface{}) ([]catalogEntry, bool) {
+	entries, err := catalogEntriesOf(value)
+	if err != nil {
+		return nil, false
+	}
+	for _, entry := range entries {
+		if entity, ok := entry.Value.(map[string]interface{}); !ok || entity["id"] != entry.ID {
+			return nil, false
+		}
+	}
+	return entries, true
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeHCLAttributes writes one attribute per line, aligning the equals signs of
+// consecutive single-line attributes the way terraform fmt does.
+func writeHCLAttributes(buf *strings.Builder, attributes []hclAttribute, indent string) {
+	for start := 0; start < len(attributes); {
+		end := start + 1
+		if !strings.Contains(attributes[start].expression, "\n") {
+			for end < len(attributes) && !strings.Contains(attributes[end].expression, "\n") {
+				end++
+			}
+		}
+		width := 0
+		for _, a := range attributes[start:end] {
+			if len(a.name) > width {
+				width = len(a.name)
+			}
+		}
+		for _, a := range attributes[start:end] {
+			fmt.Fprintf(buf, "%s%-*s = %s\n", indent, width, a.name, a.expression)
+		}
+		start = end
+	}
+}
+
+// hclJSONEncode returns a jsonencode expression for the given decoded JSON value, written in native HCL syntax.
+func hclJSONEncode(value interface{}) string {
+	return "jsonencode(" + hclValue(value, "  ") + ")"
+}
+
+// hclValue renders a value decoded by decodeJSONLosslessly as an HCL expression,
+// with nested lines indented relative to the given indentation.
+func hclValue(value interface{}, indent string) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return fmt.Sprint(v)
+	case json.Number:
+		return v.String()
+	case string:
+		return hclString(v)
+	case []interface{}:
+		if len(v) == 0 {
+			return "[]"
+		}
+		var buf strings.Builder
+		buf.WriteString("[\n")
+		for _, element := range v {
+			fmt.Fprintf(&buf, "%s  %s,\n", indent, hclValue(element, indent+"  "))
+		}
+		buf.WriteString(indent + "]")
+		return buf.String()
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return "{}"
+		}
+		attributes := make([]hclAttribute, 0, len(v))
+		for _, key := range sortedKeys(v) {
+			attributes = append(attributes, hclAttribute{hclObjectKey(key), hclValue(v[key], indent+"  ")})
+		}
+		var buf strings.Builder
+		buf.WriteString("{\n")
+		writeHCLAttributes(&buf, attributes, indent+"  ")
+		buf.WriteString(indent + "}")
+		return buf.String()
+	default:
+		panic(fmt.Sprintf("unexpected JSON value of type %T", value))
+	}
+}
+
+var hclIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// hclObjectKey returns the key unquoted if HCL would read it back as the same string.
+func hclObjectKey(key string) string {
+	switch key {
+	case "null", "true", "false", "for", "in", "if":
+		return hclString(key)
+	}
+	if hclIdentifier.MatchString(key) {
+		return key
+	}
+	return hclString(key)
+}
+
+// hclString returns a quoted HCL string literal, escaping template sequences so the value is taken literally.
+func hclString(s string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(s); err != nil {
+		panic(err)
+	}
+	quoted := strings.TrimSuffix(buf.String(), "\n")
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
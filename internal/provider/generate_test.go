@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHCLValue(t *testing.T) {
+	tests := []struct {
+		json     string
+		expected string
+	}{
+		{`null`, `null`},
+		{`12345678901234567890.5`, `12345678901234567890.5`},
+		{`"say \"${hi}\" 100%{x} <b>\n"`, `"say \"$${hi}\" 100%%{x} <b>\n"`},
+		{`[]`, `[]`},
+		{`{}`, `{}`},
+		{`[1, true]`, "[\n    1,\n    true,\n  ]"},
+		{`{"id": "a", "long_name": 1, "null": 2, "a b": 3, "nested": {"x": []}}`, strings.Join([]string{
+			`{`,
+			`    "a b"     = 3`,
+			`    id        = "a"`,
+			`    long_name = 1`,
+			`    nested = {`,
+			`      x = []`,
+			`    }`,
+			`    "null" = 2`,
+			`  }`,
+		}, "\n")},
+	}
+	for _, test := range tests {
+		value, err := decodeJSONLosslessly([]byte(test.json))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.json, err)
+		}
+		if actual := hclValue(value, "  "); actual != test.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", test.json, test.expected, actual)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+	fake.set("/bosk/", `{
+		"name": "demo",
+		"pairs": [{"x": 1}],
+		"worlds": [{"earth": {"id": "earth"}}, {"a/b": {"id": "a/b"}}],
+		"planets": {"ids": ["earth"], "domain": "/worlds"},
+		"settings": {"debug": false}
+	}`)
+
+	outDir := t.TempDir()
+	var stderr bytes.Buffer
+	err := Generate(context.Background(), []string{
+		"-base-url", testServer.URL + "/bosk",
+		"-basic-auth-var-suffix", "NO_AUTH",
+		"-depth", "2",
+		"-out", outDir,
+	}, &stderr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectFileContents(t, filepath.Join(outDir, generatedResourcesFile), `# Generated by terraform-provider-bosk from `+testServer.URL+`/bosk/
+
+resource "bosk_node" "name" {
+  path       = "/name"
+  value_json = jsonencode("demo")
+}
+
+resource "bosk_node" "pairs" {
+  path = "/pairs"
+  value_json = jsonencode([
+    {
+      x = 1
+    },
+  ])
+}
+
+resource "bosk_node" "planets" {
+  path = "/planets"
+  value_json = jsonencode({
+    domain = "/worlds"
+    ids = [
+      "earth",
+    ]
+  })
+}
+
+resource "bosk_node" "settings_debug" {
+  path       = "/settings/debug"
+  value_json = jsonencode(false)
+}
+
+resource "bosk_catalog_entry" "worlds_earth" {
+  catalog_path = "/worlds"
+  id           = "earth"
+  value_json = jsonencode({
+    id = "earth"
+  })
+}
+
+resource "bosk_catalog_entry" "worlds_a_2Fb" {
+  catalog_path = "/worlds"
+  id           = "a/b"
+  value_json = jsonencode({
+    id = "a/b"
+  })
+}
+`)
+	expectFileContents(t, filepath.Join(outDir, generatedImportsFile), `# Generated by terraform-provider-bosk from `+testServer.URL+`/bosk/
+
+import {
+  to = bosk_node.name
+  id = "/name"
+}
+
+import {
+  to = bosk_node.pairs
+  id = "/pairs"
+}
+
+import {
+  to = bosk_node.planets
+  id = "/planets"
+}
+
+import {
+  to = bosk_node.settings_debug
+  id = "/settings/debug"
+}
+
+import {
+  to = bosk_catalog_entry.worlds_earth
+  id = "/worlds/earth"
+}
+
+import {
+  to = bosk_catalog_entry.worlds_a_2Fb
+  id = "/worlds/a%2Fb"
+}
+`)
+
+	// Existing files are not overwritten
+	err = Generate(context.Background(), []string{
+		"-base-url", testServer.URL + "/bosk",
+		"-basic-auth-var-suffix", "NO_AUTH",
+		"-out", outDir,
+	}, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "remove any previously generated files") {
+		t.Errorf("expected an error about existing files; got %v", err)
+	}
+}
+
+func TestGenerateDepth(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+	fake.set("/bosk/settings", `{"debug": false, "limits": {"max": 3}, "worlds": [{"earth": {"id": "earth"}}]}`)
+
+	outDir := t.TempDir()
+	err := Generate(context.Background(), []string{
+		"-base-url", testServer.URL + "/bosk",
+		"-basic-auth-var-suffix", "NO_AUTH",
+		"-path", "settings",
+		"-depth", "2",
+		"-out", outDir,
+	}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	contents, err := os.ReadFile(filepath.Join(outDir, generatedResourcesFile))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{`path       = "/settings/debug"`, `path       = "/settings/limits/max"`, `catalog_path = "/settings/worlds"`} {
+		if !strings.Contains(string(contents), expected) {
+			t.Errorf("expected output to contain %s; got\n%s", expected, contents)
+		}
+	}
+}
+
+func TestGenerateRequiresAuthenticationSettings(t *testing.T) {
+	err := Generate(context.Background(), []string{"-base-url", "http://localhost:1740/bosk"}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "-basic-auth-var-suffix") {
+		t.Errorf("expected an error about authentication settings; got %v", err)
+	}
+}
+
+func expectFileContents(t *testing.T, name string, expected string) {
+	t.Helper()
+	actual, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(actual) != expected {
+		t.Errorf("%s: expected\n%s\ngot\n%s", filepath.Base(name), expected, actual)
+	}
+}
@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"unicode/utf16"
+)
+
+// normalizeJSON produces a canonical encoding of the given JSON document,
+// following RFC 8785 (JSON Canonicalization Scheme) except that numbers are
+// reproduced exactly as written rather than being converted to IEEE 754 doubles.
+// That way, large integers and high-precision decimals survive the round trip.
+//
+// Specifically: insignificant whitespace is removed, object members are sorted
+// by the UTF-16 code units of their names, and strings are written with the
+// minimal escaping JSON requires, leaving all other unicode characters as-is.
+func normalizeJSON(input []byte) ([]byte, error) {
+	parsed, err := decodeJSONLosslessly(input)
+	if err != nil {
+		return input, err
+	}
+	var buf bytes.Buffer
+	if err := writeCanonicalJSON(&buf, parsed); err != nil {
+		return input, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeJSONLosslessly parses a single JSON document, representing numbers as json.Number.
+func decodeJSONLosslessly(input []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.UseNumber()
+	var parsed interface{}
+	if err := decoder.Decode(&parsed); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after top-level JSON value")
+	}
+	return parsed, nil
+}
+
+func writeCanonicalJSON(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case json.Number:
+		buf.WriteString(v.String())
+	case string:
+		writeCanonicalString(buf, v)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, element := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonicalJSON(buf, element); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return lessUTF16(keys[i], keys[j])
+		})
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, key)
+			buf.WriteByte(':')
+			if err := writeCanonicalJSON(buf, v[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("unexpected JSON value of type %T", value)
+	}
+	return nil
+}
+
+// writeCanonicalString writes s as a JSON string literal per RFC 8785 section 3.2.2.2.
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// lessUTF16 orders strings by their UTF-16 code units, as RFC 8785 requires for object member names.
+func lessUTF16(a string, b string) bool {
+	unitsA := utf16.Encode([]rune(a))
+	unitsB := utf16.Encode([]rune(b))
+	for i := 0; i < len(unitsA) && i < len(unitsB); i++ {
+		if unitsA[i] != unitsB[i] {
+			return unitsA[i] < unitsB[i]
+		}
+	}
+	return len(unitsA) < len(unitsB)
+}
+
+// jsonValuesEqual compares two losslessly decoded JSON values.
+// Numbers are compared by their exact decimal value, so 1, 1.0 and 1e0 are all equal.
+func jsonValuesEqual(a interface{}, b interface{}) bool {
+	switch va := a.(type) {
+	case nil:
+		return b == nil
+	case bool:
+		vb, ok := b.(bool)
+		return ok && va == vb
+	case string:
+		vb, ok := b.(string)
+		return ok && va == vb
+	case json.Number:
+		vb, ok := b.(json.Number)
+		return ok && numbersEqual(va, vb)
+	case []interface{}:
+		vb, ok := b.([]interface{})
+		if !ok || len(va) != len(vb) {
+			return false
+		}
+		for i := range va {
+			if !jsonValuesEqual(va[i], vb[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		vb, ok := b.(map[string]interface{})
+		if !ok || len(va) != len(vb) {
+			return false
+		}
+		for key, elementA := range va {
+			elementB, present := vb[key]
+			if !present || !jsonValuesEqual(elementA, elementB) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func numbersEqual(a json.Number, b json.Number) bool {
+	if a == b {
+		return true
+	}
+	ratA, okA := new(big.Rat).SetString(a.String())
+	ratB, okB := new(big.Rat).SetString(b.String())
+	if !okA || !okB {
+		return false
+	}
+	return ratA.Cmp(ratB) == 0
+}
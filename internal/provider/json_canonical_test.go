@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestNormalizeJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"whitespace", " [ 1 , { \"a\" : true } ] ", `[1,{"a":true}]`},
+		{"key order", `{"b":1,"a":2,"A":3}`, `{"A":3,"a":2,"b":1}`},
+		{"UTF-16 key order", "{\"\U0001F600\":1,\"ﬁ\":2}", "{\"\U0001F600\":1,\"ﬁ\":2}"},
+		{"2^53 + 1", `{"id":9007199254740993}`, `{"id":9007199254740993}`},
+		{"beyond uint64", `[123456789012345678901234567890]`, `[123456789012345678901234567890]`},
+		{"negative large integer", `[-9223372036854775809]`, `[-9223372036854775809]`},
+		{"high precision decimal", `[3.14159265358979323846264338327950288]`, `[3.14159265358979323846264338327950288]`},
+		{"literal form preserved", `[1.0,1e2,1E+2,-0,0.1]`, `[1.0,1e2,1E+2,-0,0.1]`},
+		{"no HTML escaping", `{"a":"<&>"}`, `{"a":"<&>"}`},
+		{"unicode escapes decoded", `{"a":"\u00e9\u0020"}`, "{\"a\":\"é \"}"},
+		{"surrogate pair", `["\ud83d\ude00"]`, "[\"\U0001F600\"]"},
+		{"short escapes", `["\u0008\u000c\u000a\u000d\u0009\"\\\/"]`, `["\b\f\n\r\t\"\\/"]`},
+		{"other control characters", `["\u0001\u001f"]`, `["\u0001\u001f"]`},
+		{"null and booleans", `[null,true,false]`, `[null,true,false]`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := normalizeJSON([]byte(test.input))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(actual) != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNormalizeJSONRejectsInvalidInput(t *testing.T) {
+	for _, input := range []string{``, `{`, `[1,]`, `{"a":1} {"b":2}`, `01`} {
+		if _, err := normalizeJSON([]byte(input)); err == nil {
+			t.Errorf("expected error normalizing %q", input)
+		}
+	}
+}
+
+func TestNumbersComparedExactly(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected bool
+	}{
+		{`[1]`, `[1.0]`, true},
+		{`[100]`, `[1e2]`, true},
+		{`[0]`, `[-0]`, true},
+		{`[9007199254740993]`, `[9007199254740992]`, false},
+		{`[0.30000000000000004]`, `[0.3]`, false},
+		{`[123456789012345678901234567890]`, `[123456789012345678901234567891]`, false},
+	}
+	for _, test := range tests {
+		if actual := jsonEquivalent(test.a, test.b); actual != test.expected {
+			t.Errorf("expected %v comparing %s with %s", test.expected, test.a, test.b)
+		}
+	}
+}
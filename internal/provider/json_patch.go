@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Values of the update_method attribute
+const (
+	updateMethodPut        = "put"
+	updateMethodMergePatch = "merge_patch"
+	updateMethodJSONPatch  = "json_patch"
+)
+
+var updateMethods = []string{updateMethodPut, updateMethodMergePatch, updateMethodJSONPatch}
+
+// Content types of the patch documents sent for each update_method
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+)
+
+// patchDocument computes a patch that turns the base value into the target value, using the given update_method.
+// Returns the patch and its content type, or false if the method can't express the change, in which case the
+// target value should be PUT instead.
+func patchDocument(method string, baseJSON string, targetJSON string) (string, string, bool, error) {
+	base, err := decodeJSONLosslessly([]byte(baseJSON))
+	if err != nil {
+		return "", "", false, fmt.Errorf("invalid prior value: %w", err)
+	}
+	target, err := decodeJSONLosslessly([]byte(targetJSON))
+	if err != nil {
+		return "", "", false, fmt.Errorf("invalid planned value: %w", err)
+	}
+	switch method {
+	case updateMethodMergePatch:
+		patch := mergePatch(base, target)
+		if !jsonValuesEqual(applyMergePatch(base, patch), target) {
+			// A merge patch uses null to remove fields, so it can't set anything to null
+			return "", "", false, nil
+		}
+		document, err := encodeJSON(patch)
+		return document, mergePatchContentType, err == nil, err
+	case updateMethodJSONPatch:
+		operations := []interface{}{}
+		jsonPatch(nil, base, target, &operations)
+		document, err := encodeJSON(operations)
+		return document, jsonPatchContentType, err == nil, err
+	default:
+		return "", "", false, nil
+	}
+}
+
+// mergePatch returns an RFC 7396 merge patch that turns base into target.
+// Objects are compared field by field; anything else is replaced whole.
+func mergePatch(base interface{}, target interface{}) interface{} {
+	baseObject, baseIsObject := base.(map[string]interface{})
+	targetObject, targetIsObject := target.(map[string]interface{})
+	if !baseIsObject || !targetIsObject {
+		return target
+	}
+	patch := map[string]interface{}{}
+	for name := range baseObject {
+		if _, ok := targetObject[name]; !ok {
+			patch[name] = nil
+		}
+	}
+	for name, targetValue := range targetObject {
+		if baseValue, ok := baseObject[name]; !ok {
+			patch[name] = targetValue
+		} else if !jsonValuesEqual(baseValue, targetValue) {
+			patch[name] = mergePatch(baseValue, targetValue)
+		}
+	}
+	return patch
+}
+
+// applyMergePatch implements the MergePatch algorithm of RFC 7396 section 2, without modifying its arguments.
+func applyMergePatch(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	result := map[string]interface{}{}
+	if targetObject, ok := target.(map[string]interface{}); ok {
+		for name, value := range targetObject {
+			result[name] = value
+		}
+	}
+	for name, value := range patchObject {
+		if value == nil {
+			delete(result, name)
+		} else {
+			result[name] = applyMergePatch(result[name], value)
+		}
+	}
+	return result
+}
+
+// jsonPatch appends the RFC 6902 operations that turn base into target, both located at the given tokens.
+// Objects are compared field by field, and arrays of the same length element by element,
+// so that a change to one catalog entry doesn't replace the whole catalog; anything else is replaced whole.
+func jsonPatch(tokens []string, base interface{}, target interface{}, operations *[]interface{}) {
+	if jsonValuesEqual(base, target) {
+		return
+	}
+	switch baseValue := base.(type) {
+	case map[string]interface{}:
+		if targetObject, ok := target.(map[string]interface{}); ok {
+			for _, name := range sortedKeys(baseValue) {
+				if _, ok := targetObject[name]; !ok {
+					*operations = append(*operations, map[string]interface{}{"op": "remove", "path": jsonPointer(childTokens(tokens, name))})
+				}
+			}
+			for _, name := range sortedKeys(targetObject) {
+				if baseChild, ok := baseValue[name]; ok {
+					jsonPatch(childTokens(tokens, name), baseChild, targetObject[name], operations)
+				} else {
+					*operations = append(*operations, map[string]interface{}{"op": "add", "path": jsonPointer(childTokens(tokens, name)), "value": targetObject[name]})
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if targetArray, ok := target.([]interface{}); ok && len(targetArray) == len(baseValue) {
+			for i := range baseValue {
+				jsonPatch(childTokens(tokens, strconv.Itoa(i)), baseValue[i], targetArray[i], operations)
+			}
+			return
+		}
+	}
+	*operations = append(*operations, map[string]interface{}{"op": "replace", "path": jsonPointer(tokens), "value": target})
+}
+
+func childTokens(tokens []string, token string) []string {
+	return append(append([]string(nil), tokens...), token)
+}
+
+// jsonPointer is the inverse of parseJSONPointer.
+func jsonPointer(tokens []string) string {
+	if len(tokens) == 0 {
+		return ""
+	}
+	return "/" + strings.Join(escapeJSONPointerTokens(tokens), "/")
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestMergePatchDocument(t *testing.T) {
+	tests := []struct {
+		base     string
+		target   string
+		expected string // Empty if the change can't be expressed as a merge patch
+	}{
+		{`{"a":1,"b":2}`, `{"a":1,"b":2}`, `{}`},
+		{`{"a":1,"b":2}`, `{"a":1,"b":3}`, `{"b":3}`},
+		{`{"a":1,"b":2}`, `{"a":1}`, `{"b":null}`},
+		{`{"a":1}`, `{"a":1,"c":{"d":4}}`, `{"c":{"d":4}}`},
+		{`{"a":{"b":1,"c":2}}`, `{"a":{"b":1,"c":3}}`, `{"a":{"c":3}}`},
+		{`{"a":[1,2]}`, `{"a":[1,3]}`, `{"a":[1,3]}`},
+		{`[1,2]`, `{"a":1}`, `{"a":1}`},
+		{`{"a":1}`, `{"a":null}`, ``},
+		{`{"a":{"b":1}}`, `{"a":{"b":{"c":null}}}`, ``},
+	}
+	for _, test := range tests {
+		patch, contentType, ok, err := patchDocument(updateMethodMergePatch, test.base, test.target)
+		if err != nil {
+			t.Errorf("%s -> %s: unexpected error: %s", test.base, test.target, err)
+			continue
+		}
+		if test.expected == "" {
+			if ok {
+				t.Errorf("%s -> %s: expected no merge patch; got %s", test.base, test.target, patch)
+			}
+			continue
+		}
+		if !ok || patch != test.expected || contentType != mergePatchContentType {
+			t.Errorf("%s -> %s: expected %s; got %s (%v, %s)", test.base, test.target, test.expected, patch, ok, contentType)
+		}
+	}
+}
+
+func TestJSONPatchDocument(t *testing.T) {
+	tests := []struct {
+		base     string
+		target   string
+		expected string
+	}{
+		{`{"a":1}`, `{"a":1}`, `[]`},
+		{`{"a":1,"b":2}`, `{"a":1,"b":null}`, `[{"op":"replace","path":"/b","value":null}]`},
+		{`{"a":1,"b":2}`, `{"c":3,"b":2}`, `[{"op":"remove","path":"/a"},{"op":"add","path":"/c","value":3}]`},
+		{`{"a/b":{"m~n":1}}`, `{"a/b":{"m~n":2}}`, `[{"op":"replace","path":"/a~1b/m~0n","value":2}]`},
+		{
+			`[{"earth":{"id":"earth","moons":1}},{"mars":{"id":"mars"}}]`,
+			`[{"earth":{"id":"earth","moons":2}},{"mars":{"id":"mars"}}]`,
+			`[{"op":"replace","path":"/0/earth/moons","value":2}]`,
+		},
+		{`{"a":[1,2]}`, `{"a":[1,2,3]}`, `[{"op":"replace","path":"/a","value":[1,2,3]}]`},
+		{`{"a":1}`, `"whole"`, `[{"op":"replace","path":"","value":"whole"}]`},
+	}
+	for _, test := range tests {
+		patch, contentType, ok, err := patchDocument(updateMethodJSONPatch, test.base, test.target)
+		if err != nil || !ok {
+			t.Errorf("%s -> %s: unexpected failure: %v %s", test.base, test.target, ok, err)
+			continue
+		}
+		if patch != test.expected || contentType != jsonPatchContentType {
+			t.Errorf("%s -> %s: expected %s; got %s (%s)", test.base, test.target, test.expected, patch, contentType)
+		}
+	}
+}
+
+func TestPatchFallsBackOnMethodNotAllowed(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+	fake.set("/bosk/node", `{"id":"node"}`)
+
+	client := NewBoskClientWithoutAuth(http.DefaultClient, BoskClientSettings{RevisionHeader: "ETag"})
+	url := testServer.URL + "/bosk/node"
+	var diags diag.Diagnostics
+	ctx := context.Background()
+
+	_, revision := client.GetJSONAsString(ctx, url, &diags)
+	_, supported := client.PatchJSONAsString(ctx, url, `{"x":1}`, mergePatchContentType, revision, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if supported {
+		t.Errorf("expected 405 to report PATCH as unsupported")
+	}
+
+	fake.enableMergePatch()
+	newRevision, supported := client.PatchJSONAsString(ctx, url, `{"x":1}`, mergePatchContentType, revision, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if !supported || newRevision == "" || newRevision == revision {
+		t.Errorf("expected PATCH to report a new revision; got %q (%v) after %q", newRevision, supported, revision)
+	}
+	if actual, _ := fake.get("/bosk/node"); actual != `{"id":"node","x":1}` {
+		t.Errorf("expected patched node; got %s", actual)
+	}
+}
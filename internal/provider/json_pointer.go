@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseJSONPointer splits an RFC 6901 JSON Pointer into its unescaped reference tokens.
+// The empty pointer refers to the whole document and has no tokens.
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("JSON pointer %q must be empty or start with \"/\"", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		for j := 0; j < len(token); j++ {
+			if token[j] == '~' && (j+1 == len(token) || (token[j+1] != '0' && token[j+1] != '1')) {
+				return nil, fmt.Errorf("JSON pointer %q contains \"~\" not followed by \"0\" or \"1\"", pointer)
+			}
+		}
+		// Order matters: "~01" must become "~1", not "/"
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// resolveJSONPointer evaluates an RFC 6901 JSON Pointer against a document
+// decoded by decodeJSONLosslessly, returning the referenced value.
+func resolveJSONPointer(document interface{}, pointer string) (interface{}, error) {
+	tokens, err := parseJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	current := document
+	for i, token := range tokens {
+		location := "/" + strings.Join(escapeJSONPointerTokens(tokens[:i+1]), "/")
+		switch node := current.(type) {
+		case map[string]interface{}:
+			member, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("no member %q at %q", token, location)
+			}
+			current = member
+		case []interface{}:
+			index, err := arrayIndex(token)
+			if err != nil {
+				return nil, fmt.Errorf("invalid array index at %q: %s", location, err)
+			}
+			if index >= len(node) {
+				return nil, fmt.Errorf("array index %d out of bounds at %q; array has %d elements", index, location, len(node))
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("cannot descend into a scalar value at %q", location)
+		}
+	}
+	return current, nil
+}
+
+// arrayIndex parses an array reference token, which RFC 6901 requires to be
+// a non-negative decimal integer without leading zeros.
+func arrayIndex(token string) (int, error) {
+	if token == "-" {
+		return 0, fmt.Errorf("\"-\" refers to a nonexistent element past the end of the array")
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.TrimLeft(token, "0123456789") != "" {
+		return 0, fmt.Errorf("%q is not a valid array index", token)
+	}
+	return strconv.Atoi(token)
+}
+
+func escapeJSONPointerTokens(tokens []string) []string {
+	escaped := make([]string, len(tokens))
+	for i, token := range tokens {
+		escaped[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+	}
+	return escaped
+}
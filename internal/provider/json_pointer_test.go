@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestResolveJSONPointer(t *testing.T) {
+	// The example document from RFC 6901 section 5
+	document, err := decodeJSONLosslessly([]byte(`{
+		"foo": ["bar", "baz"],
+		"": 0,
+		"a/b": 1,
+		"c%d": 2,
+		"e^f": 3,
+		"g|h": 4,
+		"i\\j": 5,
+		"k\"l": 6,
+		" ": 7,
+		"m~n": 8
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		pointer  string
+		expected string
+	}{
+		{"", `{"":0," ":7,"a/b":1,"c%d":2,"e^f":3,"foo":["bar","baz"],"g|h":4,"i\\j":5,"k\"l":6,"m~n":8}`},
+		{"/foo", `["bar","baz"]`},
+		{"/foo/0", `"bar"`},
+		{"/", `0`},
+		{"/a~1b", `1`},
+		{"/c%d", `2`},
+		{"/e^f", `3`},
+		{"/g|h", `4`},
+		{"/i\\j", `5`},
+		{"/k\"l", `6`},
+		{"/ ", `7`},
+		{"/m~0n", `8`},
+	}
+	for _, test := range tests {
+		selected, err := resolveJSONPointer(document, test.pointer)
+		if err != nil {
+			t.Errorf("unexpected error resolving %q: %s", test.pointer, err)
+			continue
+		}
+		var buf bytes.Buffer
+		if err := writeCanonicalJSON(&buf, selected); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != test.expected {
+			t.Errorf("resolving %q: expected %s, got %s", test.pointer, test.expected, buf.String())
+		}
+	}
+}
+
+func TestResolveJSONPointerErrors(t *testing.T) {
+	document, err := decodeJSONLosslessly([]byte(`{"foo":["bar","baz"],"n":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pointer := range []string{
+		"foo",        // missing leading slash
+		"/missing",   // no such member
+		"/foo/2",     // out of bounds
+		"/foo/-",     // past the end
+		"/foo/01",    // leading zero
+		"/foo/x",     // not an index
+		"/n/0",       // scalar
+		"/foo~2",     // bad escape
+		"/foo~",      // bad escape
+		"/foo/0/bar", // scalar
+	} {
+		if _, err := resolveJSONPointer(document, pointer); err == nil {
+			t.Errorf("expected error resolving %q", pointer)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the JSON types fully satisfy framework interfaces.
+var _ basetypes.StringTypable = JSONType{}
+var _ basetypes.StringValuableWithSemanticEquals = JSONValue{}
+
+// JSONType is a string type holding a JSON document.
+// Its values are compared semantically, so that differences in whitespace,
+// object key order, and string escaping don't show up as changes in a plan.
+type JSONType struct {
+	basetypes.StringType
+}
+
+func (t JSONType) Equal(o attr.Type) bool {
+	other, ok := o.(JSONType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t JSONType) String() string {
+	return "JSONType"
+}
+
+func (t JSONType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return JSONValue{StringValue: in}, nil
+}
+
+func (t JSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return JSONValue{StringValue: stringValue}, nil
+}
+
+func (t JSONType) ValueType(ctx context.Context) attr.Value {
+	return JSONValue{}
+}
+
+// JSONValue is a value of JSONType.
+type JSONValue struct {
+	basetypes.StringValue
+}
+
+func NewJSONValue(value string) JSONValue {
+	return JSONValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func NewJSONNull() JSONValue {
+	return JSONValue{StringValue: basetypes.NewStringNull()}
+}
+
+func NewJSONUnknown() JSONValue {
+	return JSONValue{StringValue: basetypes.NewStringUnknown()}
+}
+
+func (v JSONValue) Equal(o attr.Value) bool {
+	other, ok := o.(JSONValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v JSONValue) Type(ctx context.Context) attr.Type {
+	return JSONType{}
+}
+
+// StringSemanticEquals reports whether both values are the same JSON document.
+// Values that don't parse as JSON are only equal if they are textually identical.
+func (v JSONValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(JSONValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return jsonEquivalent(v.ValueString(), newValue.ValueString()), diags
+}
+
+// jsonEquivalent reports whether the two strings encode the same JSON document.
+func jsonEquivalent(a string, b string) bool {
+	if a == b {
+		return true
+	}
+	parsedA, err := decodeJSONLosslessly([]byte(a))
+	if err != nil {
+		return false
+	}
+	parsedB, err := decodeJSONLosslessly([]byte(b))
+	if err != nil {
+		return false
+	}
+	return jsonValuesEqual(parsedA, parsedB)
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestJSONSemanticEquals(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected bool
+	}{
+		{"identical", `{"a":1}`, `{"a":1}`, true},
+		{"whitespace", `{ "a" : [ 1, 2 ] }`, `{"a":[1,2]}`, true},
+		{"key order", `{"b":1,"a":2}`, `{"a":2,"b":1}`, true},
+		{"nested key order", `[{"x":{"b":1,"a":2}}]`, `[{"x":{"a":2,"b":1}}]`, true},
+		{"html escapes", `{"a":"<&>"}`, `{"a":"<&>"}`, true},
+		{"unicode escapes", `{"a":"é"}`, `{"a":"é"}`, true},
+		{"different value", `{"a":1}`, `{"a":2}`, false},
+		{"array order", `[1,2]`, `[2,1]`, false},
+		{"extra key", `{"a":1}`, `{"a":1,"b":2}`, false},
+		{"invalid JSON", `{"a":`, `{"a":1}`, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, diags := NewJSONValue(test.a).StringSemanticEquals(context.Background(), NewJSONValue(test.b))
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if actual != test.expected {
+				t.Errorf("expected %v comparing %s with %s", test.expected, test.a, test.b)
+			}
+		})
+	}
+}
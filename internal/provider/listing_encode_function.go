@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ListingEncodeFunction{}
+
+func NewListingEncodeFunction() function.Function {
+	return &ListingEncodeFunction{}
+}
+
+// ListingEncodeFunction builds bosk's Listing encoding from a list of ids.
+type ListingEncodeFunction struct{}
+
+func (f *ListingEncodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "listing_encode"
+}
+
+func (f *ListingEncodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Encodes a bosk Listing",
+		MarkdownDescription: "Returns the JSON encoding bosk uses for a Listing, like `{\"domain\":\"/worlds\",\"ids\":[\"earth\",\"mars\"]}`. The ids keep the order in which they're given.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "ids",
+				MarkdownDescription: "The ids in the listing. Must not contain duplicates.",
+				ElementType:         types.StringType,
+			},
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "The bosk path of the catalog containing the listed entries, such as `/worlds`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ListingEncodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ids []string
+	var domain string
+
+	resp.Error = req.Arguments.Get(ctx, &ids, &domain)
+	if resp.Error != nil {
+		return
+	}
+
+	if problem := checkReference(domain); problem != "" {
+		resp.Error = function.NewArgumentFuncError(1, problem)
+		return
+	}
+	result, err := encodeListing(ids, domain)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
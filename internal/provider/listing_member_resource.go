@@ -0,0 +1,319 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &ListingMemberResource{}
+var _ resource.ResourceWithImportState = &ListingMemberResource{}
+var _ resource.ResourceWithValidateConfig = &ListingMemberResource{}
+var _ resource.ResourceWithModifyPlan = &ListingMemberResource{}
+
+// listingEntryJSON is how bosk serializes the value of a single Listing entry.
+const listingEntryJSON = "true"
+
+func NewListingMemberResource() resource.Resource {
+	return &ListingMemberResource{}
+}
+
+// ListingMemberResource manages the presence of a single id in a bosk Listing,
+// leaving the listing's other ids to be managed independently.
+type ListingMemberResource struct {
+	client *BoskClient
+}
+
+type ListingMemberModel struct {
+	ListingURL  types.String   `tfsdk:"listing_url"`
+	ListingPath types.String   `tfsdk:"listing_path"`
+	ID          types.String   `tfsdk:"id"`
+	URL         types.String   `tfsdk:"url"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *ListingMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_listing_member"
+}
+
+func (r *ListingMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A single id in a bosk Listing. Bosk serializes a listing as `{\"ids\":[...],\"domain\":\"...\"}`; this resource adds one id, using bosk's per-entry `PUT` and `DELETE` on `<listing>/<id>`, so different configurations can manage different ids in the same listing.",
+
+		Attributes: map[string]schema.Attribute{
+			"listing_url": schema.StringAttribute{
+				MarkdownDescription: "The HTTP address of the listing. Exactly one of `listing_url` and `listing_path` must be specified.",
+				Optional:            true,
+				Computed:            true,
+				Validators:          []validator.String{httpURLValidator{}},
+			},
+			"listing_path": schema.StringAttribute{
+				MarkdownDescription: "The location of the listing relative to the provider's `base_url`. Exactly one of `listing_url` and `listing_path` must be specified.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id to add to the listing. Changing it replaces the member.",
+				Required:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The HTTP address of the listing entry, formed by appending `id` to the listing's address.",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *ListingMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BoskClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *BoskClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	// A listing member has no value_json, and no url of its own that could be wrong
+	r.client = client.withErrorAttributes(errorAttributes{})
+}
+
+// ResolveURL fills in the listing_url field from listing_path, and the url field from listing_url and id.
+func (m *ListingMemberModel) ResolveURL(client *BoskClient, diag *diag.Diagnostics) {
+	m.ListingURL, m.URL = resolveEntryURL(client, m.ListingURL, m.ListingPath, m.ID, "listing", diag)
+}
+
+func (m *ListingMemberModel) url() string {
+	return m.URL.ValueString()
+}
+
+func (r *ListingMemberResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ListingMemberModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateAddress(data.ListingURL, data.ListingPath, path.Root("listing_url"), path.Root("listing_path"), &resp.Diagnostics)
+}
+
+func (r *ListingMemberResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy, or if the provider has not been configured yet.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var data ListingMemberModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Resolve the address now so the plan shows the actual url
+	data.ResolveURL(r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+	if !data.URL.IsUnknown() {
+		r.client.ClaimNode(ctx, resp.Private, data.url(), nil, path.Root("url"), &resp.Diagnostics)
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var state ListingMemberModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A different id or listing is a different member
+	if !data.URL.Equal(state.URL) {
+		resp.RequiresReplace.Append(path.Root("url"))
+	}
+}
+
+func (r *ListingMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		addUnconfiguredError(&resp.Diagnostics)
+		return
+	}
+
+	var data ListingMemberModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	data.ResolveURL(r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.client.PutJSONAsString(ctx, data.url(), listingEntryJSON, "", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "added bosk listing member", map[string]interface{}{
+		"url": data.url(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ListingMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// While the provider defers its configuration, keep the prior state
+	if r.client == nil {
+		return
+	}
+
+	var data ListingMemberModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	contents := r.client.GetNode(ctx, data.url(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !contents.Found {
+		// Removed out of band; let Terraform plan to add it again
+		tflog.Info(ctx, "bosk listing member not found; removing from state", map[string]interface{}{
+			"url": data.url(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update has nothing to send to the server, because every change to the
+// member's address requires replacement; it just records changed timeouts.
+func (r *ListingMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client == nil {
+		addUnconfiguredError(&resp.Diagnostics)
+		return
+	}
+
+	var data ListingMemberModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ListingMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		addUnconfiguredError(&resp.Diagnostics)
+		return
+	}
+
+	var data ListingMemberModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	r.client.Delete(ctx, data.url(), "", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "removed bosk listing member", map[string]interface{}{
+		"url": data.url(),
+	})
+}
+
+// ImportState accepts <listing>/<id>, where <listing> is either an absolute url,
+// or a path relative to the provider's base_url.
+func (r *ListingMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.client == nil {
+		addUnconfiguredError(&resp.Diagnostics)
+		return
+	}
+
+	listing, id, err := splitEntryAddress(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	data := ListingMemberModel{
+		ID:       types.StringValue(id),
+		Timeouts: nullTimeoutsFor("create", "read", "delete"),
+	}
+	data.ListingURL, data.ListingPath = containerAddress(listing)
+	data.ResolveURL(r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Confirm the member exists
+	r.client.GetJSONAsString(ctx, data.url(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "imported bosk listing member", map[string]interface{}{
+		"url": data.url(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
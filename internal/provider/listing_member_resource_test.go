@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccListingMemberResource(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+	fake.set("/bosk/visited/venus", listingEntryJSON)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccListingMemberResourceConfig(testServer.URL+"/bosk/", "earth"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bosk_listing_member.test", "listing_url", testServer.URL+"/bosk/visited"),
+					resource.TestCheckResourceAttr("bosk_listing_member.test", "url", testServer.URL+"/bosk/visited/earth"),
+					func(*terraform.State) error {
+						if value, ok := fake.get("/bosk/visited/earth"); !ok || value != listingEntryJSON {
+							return fmt.Errorf("listing entry was not added; got %q", value)
+						}
+						return nil
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "bosk_listing_member.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "url",
+				ImportStateId:                        "visited/earth",
+			},
+			// Out-of-band removal is planned as re-adding
+			{
+				PreConfig: func() {
+					fake.remove("/bosk/visited/earth")
+				},
+				Config: testAccListingMemberResourceConfig(testServer.URL+"/bosk/", "earth"),
+				Check: func(*terraform.State) error {
+					if _, ok := fake.get("/bosk/visited/earth"); !ok {
+						return fmt.Errorf("listing entry was not re-added")
+					}
+					return nil
+				},
+			},
+			// A new id replaces the member
+			{
+				Config: testAccListingMemberResourceConfig(testServer.URL+"/bosk/", "mars"),
+				Check: func(*terraform.State) error {
+					if _, ok := fake.get("/bosk/visited/earth"); ok {
+						return fmt.Errorf("old listing entry was left behind")
+					}
+					return nil
+				},
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			if _, ok := fake.get("/bosk/visited/mars"); ok {
+				return fmt.Errorf("listing entry was not removed")
+			}
+			if _, ok := fake.get("/bosk/visited/venus"); !ok {
+				return fmt.Errorf("unmanaged listing entry was removed")
+			}
+			return nil
+		},
+	})
+}
+
+func testAccListingMemberResourceConfig(base, id string) string {
+	return fmt.Sprintf(`
+		provider "bosk" {
+			basic_auth_var_suffix = "NO_AUTH"
+			base_url              = "%s"
+		}
+		resource "bosk_listing_member" "test" {
+			listing_path = "visited"
+			id           = "%s"
+		}
+	`, base, id)
+}
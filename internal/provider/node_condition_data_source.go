@@ -0,0 +1,330 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &NodeConditionDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &NodeConditionDataSource{}
+
+func NewNodeConditionDataSource() datasource.DataSource {
+	return &NodeConditionDataSource{}
+}
+
+// NodeConditionDataSource polls a bosk node until part of it satisfies a condition.
+type NodeConditionDataSource struct {
+	client *BoskClient
+}
+
+type NodeConditionDataSourceModel struct {
+	URL         types.String   `tfsdk:"url"`
+	Path        types.String   `tfsdk:"path"`
+	Pointer     types.String   `tfsdk:"pointer"`
+	Expected    JSONValue      `tfsdk:"expected_value_json"`
+	Matches     types.String   `tfsdk:"matches"`
+	Interval    types.String   `tfsdk:"interval"`
+	Value_json  JSONValue      `tfsdk:"value_json"`
+	Result_json JSONValue      `tfsdk:"result_json"`
+	ETag        types.String   `tfsdk:"etag"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// defaultPollInterval is the time between attempts when interval is not specified.
+const defaultPollInterval = 5 * time.Second
+
+func (d *NodeConditionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_node_condition"
+}
+
+func (d *NodeConditionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Waits for a bosk node to reach a particular state, such as a service reporting `/status/ready` as `true`, by reading it repeatedly until the value at `pointer` satisfies the condition. " +
+			"With `expected_value_json`, the value must equal it; with `matches`, it must match a regular expression; with neither, it need only exist. " +
+			"Fails if the condition isn't met within the `read` timeout, which defaults to 20 minutes. " +
+			"Like any data source, it's read during planning unless it depends on something not yet known, so give it a `depends_on` for the resources it's waiting for.",
+
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The HTTP address of the bosk node. Exactly one of `url` and `path` must be specified.",
+				Optional:            true,
+				Computed:            true,
+				Validators:          []validator.String{httpURLValidator{}},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The location of the bosk node relative to the provider's `base_url`. Exactly one of `url` and `path` must be specified.",
+				Optional:            true,
+			},
+			"pointer": schema.StringAttribute{
+				MarkdownDescription: "An [RFC 6901](https://www.rfc-editor.org/rfc/rfc6901) JSON Pointer, such as `/status/ready`, locating the value to check within the node. Defaults to the empty pointer, which selects the whole node.",
+				Optional:            true,
+			},
+			"expected_value_json": schema.StringAttribute{
+				MarkdownDescription: "The JSON-encoded value to wait for at `pointer`. Compared as JSON, not as text. At most one of `expected_value_json` and `matches` may be specified.",
+				CustomType:          JSONType{},
+				Optional:            true,
+				Validators:          []validator.String{jsonValidator{}},
+			},
+			"matches": schema.StringAttribute{
+				MarkdownDescription: "A [Go regular expression](https://pkg.go.dev/regexp/syntax) to wait for the value at `pointer` to match. Strings are matched as they are; other values are matched against their JSON encoding. At most one of `expected_value_json` and `matches` may be specified.",
+				Optional:            true,
+			},
+			"interval": schema.StringAttribute{
+				MarkdownDescription: "The time between reads of the node, as a Go duration such as `10s`. Defaults to `5s`.",
+				Optional:            true,
+			},
+			"value_json": schema.StringAttribute{
+				MarkdownDescription: "The JSON-encoded contents of the node once the condition was met.",
+				CustomType:          JSONType{},
+				Computed:            true,
+			},
+			"result_json": schema.StringAttribute{
+				MarkdownDescription: "The JSON-encoded value at `pointer` once the condition was met.",
+				CustomType:          JSONType{},
+				Computed:            true,
+			},
+			"etag": schema.StringAttribute{
+				MarkdownDescription: "The revision of the node once the condition was met, as reported by the server. Null if the server doesn't report revisions.",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *NodeConditionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BoskClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *BoskClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client.withErrorAttributes(errorAttributes{address: path.Root("url")})
+}
+
+func (d *NodeConditionDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data NodeConditionDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateAddress(data.URL, data.Path, path.Root("url"), path.Root("path"), &resp.Diagnostics)
+	if !data.Pointer.IsUnknown() {
+		if _, err := parseJSONPointer(data.Pointer.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("pointer"), "Invalid JSON pointer", err.Error())
+		}
+	}
+	if !data.Expected.IsNull() && !data.Matches.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("matches"),
+			"Conflicting conditions",
+			"Only one of expected_value_json and matches may be specified",
+		)
+	}
+	if !data.Matches.IsNull() && !data.Matches.IsUnknown() {
+		if _, err := regexp.Compile(data.Matches.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("matches"), "Invalid regular expression", err.Error())
+		}
+	}
+	if !data.Interval.IsUnknown() {
+		data.interval(&resp.Diagnostics)
+	}
+}
+
+// interval returns the configured time between reads, or the default if there is none.
+func (m *NodeConditionDataSourceModel) interval(diags *diag.Diagnostics) time.Duration {
+	interval := parseDurationAttribute(m.Interval, path.Root("interval"), defaultPollInterval, diags)
+	if interval == 0 {
+		diags.AddAttributeError(path.Root("interval"), "Invalid duration", "Expected a positive duration. Got: "+m.Interval.ValueString())
+	}
+	return interval
+}
+
+// condition returns the condition described by the configuration.
+func (m *NodeConditionDataSourceModel) condition(diags *diag.Diagnostics) nodeCondition {
+	condition := nodeCondition{pointer: m.Pointer.ValueString()}
+	if !m.Expected.IsNull() {
+		expected, err := decodeJSONLosslessly([]byte(m.Expected.ValueString()))
+		if err != nil {
+			diags.AddAttributeError(path.Root("expected_value_json"), "Invalid JSON", err.Error())
+		}
+		condition.expected = expected
+		condition.hasExpected = true
+	}
+	if !m.Matches.IsNull() {
+		pattern, err := regexp.Compile(m.Matches.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("matches"), "Invalid regular expression", err.Error())
+		}
+		condition.pattern = pattern
+	}
+	return condition
+}
+
+func (d *NodeConditionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		addUnconfiguredError(&resp.Diagnostics)
+		return
+	}
+
+	var data NodeConditionDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	data.URL = resolveURL(d.client, data.URL, data.Path, path.Root("url"), path.Root("path"), &resp.Diagnostics)
+	interval := data.interval(&resp.Diagnostics)
+	condition := data.condition(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	contents, selected := waitForCondition(ctx, d.client, data.URL.ValueString(), condition, interval, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Value_json = NewJSONValue(contents.JSON)
+	data.Result_json = NewJSONValue(selected)
+	if contents.Revision == "" {
+		data.ETag = types.StringNull()
+	} else {
+		data.ETag = types.StringValue(contents.Revision)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// waitForCondition reads the node at url every interval until the condition is met or the context ends.
+// Returns the node's contents and the canonical JSON of the value at the condition's pointer.
+// A missing node, or a value missing from the node, simply doesn't meet the condition.
+func waitForCondition(ctx context.Context, client *BoskClient, url string, condition nodeCondition, interval time.Duration, diags *diag.Diagnostics) (NodeContents, string) {
+	started := time.Now()
+	lastObserved := "node not found"
+	for attempt := 1; ; attempt++ {
+		var readDiags diag.Diagnostics
+		contents := client.GetNode(ctx, url, &readDiags)
+		if readDiags.HasError() && ctx.Err() == nil {
+			diags.Append(readDiags...)
+			return NodeContents{}, ""
+		}
+		if contents.Found {
+			selected, met, err := condition.check(contents.JSON)
+			if err != nil {
+				diags.AddAttributeError(path.Root("url"), "Node is not valid JSON", err.Error())
+				return NodeContents{}, ""
+			}
+			if met {
+				tflog.Debug(ctx, "bosk node condition met", map[string]interface{}{
+					"url":      url,
+					"attempts": attempt,
+				})
+				return contents, selected
+			}
+			lastObserved = selected
+			if selected == "" {
+				lastObserved = "no value at pointer"
+			}
+		}
+		tflog.Debug(ctx, "Waiting for bosk node condition", map[string]interface{}{
+			"url":      url,
+			"attempt":  attempt,
+			"observed": lastObserved,
+		})
+		if ctx.Err() != nil || !sleepContext(ctx, interval) {
+			diags.AddError(
+				"Condition not met",
+				fmt.Sprintf("Gave up after %v waiting for %v at %v. Last observed: %s", time.Since(started).Round(time.Second), condition, url, lastObserved),
+			)
+			return NodeContents{}, ""
+		}
+	}
+}
+
+// nodeCondition is a test applied to the value at a JSON pointer within a node.
+type nodeCondition struct {
+	pointer string
+
+	// expected is the value to compare against, if hasExpected is true
+	expected    interface{}
+	hasExpected bool
+
+	// pattern is the regular expression to match, or nil
+	pattern *regexp.Regexp
+}
+
+// check reports whether the node satisfies the condition, along with the canonical JSON of the value at the pointer,
+// which is empty if there is no such value.
+func (c nodeCondition) check(nodeJSON string) (string, bool, error) {
+	node, err := decodeJSONLosslessly([]byte(nodeJSON))
+	if err != nil {
+		return "", false, err
+	}
+	selected, err := resolveJSONPointer(node, c.pointer)
+	if err != nil {
+		// Not there yet
+		return "", false, nil
+	}
+	selectedJSON, err := encodeJSON(selected)
+	if err != nil {
+		return "", false, err
+	}
+	switch {
+	case c.hasExpected:
+		return selectedJSON, jsonValuesEqual(selected, c.expected), nil
+	case c.pattern != nil:
+		if s, ok := selected.(string); ok {
+			return selectedJSON, c.pattern.MatchString(s), nil
+		}
+		return selectedJSON, c.pattern.MatchString(selectedJSON), nil
+	default:
+		return selectedJSON, true, nil
+	}
+}
+
+func (c nodeCondition) String() string {
+	subject := "the node"
+	if c.pointer != "" {
+		subject = fmt.Sprintf("%q", c.pointer)
+	}
+	switch {
+	case c.hasExpected:
+		expectedJSON, _ := encodeJSON(c.expected)
+		return fmt.Sprintf("%s to equal %s", subject, expectedJSON)
+	case c.pattern != nil:
+		return fmt.Sprintf("%s to match %q", subject, c.pattern)
+	default:
+		return fmt.Sprintf("%s to exist", subject)
+	}
+}
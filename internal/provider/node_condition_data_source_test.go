@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestNodeConditionCheck(t *testing.T) {
+	const node = `{"status":{"ready":true,"phase":"Running","replicas":3}}`
+	tests := []struct {
+		condition nodeCondition
+		selected  string
+		met       bool
+	}{
+		{nodeCondition{pointer: "/status/ready"}, `true`, true},
+		{nodeCondition{pointer: "/status/missing"}, ``, false},
+		{nodeCondition{pointer: "/status/ready", expected: true, hasExpected: true}, `true`, true},
+		{nodeCondition{pointer: "/status/ready", expected: false, hasExpected: true}, `true`, false},
+		{nodeCondition{pointer: "/status/missing", expected: nil, hasExpected: true}, ``, false},
+		{nodeCondition{pointer: "/status/phase", pattern: regexp.MustCompile(`^Run`)}, `"Running"`, true},
+		{nodeCondition{pointer: "/status/phase", pattern: regexp.MustCompile(`^"Run`)}, `"Running"`, false},
+		{nodeCondition{pointer: "/status/replicas", pattern: regexp.MustCompile(`^[1-9]$`)}, `3`, true},
+		{nodeCondition{pointer: "/status", pattern: regexp.MustCompile(`"ready":true`)}, `{"phase":"Running","ready":true,"replicas":3}`, true},
+	}
+	for _, test := range tests {
+		selected, met, err := test.condition.check(node)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %s", test.condition, err)
+			continue
+		}
+		if selected != test.selected || met != test.met {
+			t.Errorf("%v: expected %q, %v; got %q, %v", test.condition, test.selected, test.met, selected, met)
+		}
+	}
+}
+
+func TestWaitForCondition(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+
+	client := NewBoskClientWithoutAuth(http.DefaultClient, BoskClientSettings{RevisionHeader: "ETag"})
+	url := testServer.URL + "/bosk/status"
+	condition := nodeCondition{pointer: "/ready", expected: true, hasExpected: true}
+
+	// The node doesn't exist at first, then isn't ready, then is
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		fake.set("/bosk/status", `{"ready":false}`)
+		time.Sleep(50 * time.Millisecond)
+		fake.set("/bosk/status", `{"ready":true}`)
+	}()
+
+	var diags diag.Diagnostics
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	contents, selected := waitForCondition(ctx, client, url, condition, 10*time.Millisecond, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if contents.JSON != `{"ready":true}` || selected != `true` || contents.Revision == "" {
+		t.Errorf("expected the ready node; got %+v, %s", contents, selected)
+	}
+}
+
+func TestWaitForConditionTimesOut(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+	fake.set("/bosk/status", `{"ready":false}`)
+
+	client := NewBoskClientWithoutAuth(http.DefaultClient, BoskClientSettings{})
+	condition := nodeCondition{pointer: "/ready", expected: true, hasExpected: true}
+
+	var diags diag.Diagnostics
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	waitForCondition(ctx, client, testServer.URL+"/bosk/status", condition, 10*time.Millisecond, &diags)
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Condition not met" {
+		t.Fatalf("expected the wait to time out; got %v", diags)
+	}
+	if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, `"/ready" to equal true`) || !strings.Contains(detail, "Last observed: false") {
+		t.Errorf("expected the timeout to describe the condition and the last value; got %s", detail)
+	}
+}
+
+func TestWaitForConditionTimesOutWhileNotFound(t *testing.T) {
+	_, testServer := newFakeBosk(t)
+	defer testServer.Close()
+
+	client := NewBoskClientWithoutAuth(http.DefaultClient, BoskClientSettings{})
+	condition := nodeCondition{pointer: "/ready"}
+
+	var diags diag.Diagnostics
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	waitForCondition(ctx, client, testServer.URL+"/bosk/status", condition, 10*time.Millisecond, &diags)
+	if len(diags.Errors()) != 1 || diags.Errors()[0].Summary() != "Condition not met" {
+		t.Fatalf("expected the wait to continue until it timed out; got %v", diags)
+	}
+	if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, "Last observed: node not found") {
+		t.Errorf("expected the timeout to report that the node was never found; got %s", detail)
+	}
+}
+
+func TestAccNodeConditionDataSource(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+
+	// The node doesn't exist at first, then isn't ready, then is
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		fake.set("/bosk/service", `{"status":{"ready":false}}`)
+		time.Sleep(500 * time.Millisecond)
+		fake.set("/bosk/service", `{"status":{"ready":true}}`)
+	}()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "bosk" {
+						basic_auth_var_suffix = "NO_AUTH"
+					}
+					data "bosk_node_condition" "ready" {
+						url                 = "%s/bosk/service"
+						pointer             = "/status/ready"
+						expected_value_json = jsonencode(true)
+						interval            = "100ms"
+					}
+				`, testServer.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bosk_node_condition.ready", "result_json", "true"),
+					resource.TestCheckResourceAttr("data.bosk_node_condition.ready", "value_json", `{"status":{"ready":true}}`),
+					resource.TestCheckResourceAttrSet("data.bosk_node_condition.ready", "etag"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNodeConditionDataSourceTimeout(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+	fake.set("/bosk/service", `{"status":{"phase":"Pending"}}`)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "bosk" {
+						basic_auth_var_suffix = "NO_AUTH"
+					}
+					data "bosk_node_condition" "running" {
+						url      = "%s/bosk/service"
+						pointer  = "/status/phase"
+						matches  = "^Running$"
+						interval = "100ms"
+						timeouts {
+							read = "1s"
+						}
+					}
+				`, testServer.URL),
+				ExpectError: regexp.MustCompile(`Condition not met`),
+			},
+		},
+	})
+}
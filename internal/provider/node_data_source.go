@@ -4,17 +4,22 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &NodeDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &NodeDataSource{}
 
 func NewNodeDataSource() datasource.DataSource {
 	return &NodeDataSource{}
@@ -25,6 +30,26 @@ type NodeDataSource struct {
 	client *BoskClient
 }
 
+// NodeDataSourceModel describes the data source data model.
+type NodeDataSourceModel struct {
+	URL         types.String `tfsdk:"url"`
+	Path        types.String `tfsdk:"path"`
+	Value_json  JSONValue    `tfsdk:"value_json"`
+	ETag        types.String `tfsdk:"etag"`
+	Pointer     types.String `tfsdk:"pointer"`
+	Result_json JSONValue    `tfsdk:"result_json"`
+}
+
+// node returns the fields shared with the bosk_node resource.
+func (m *NodeDataSourceModel) node() NodeModel {
+	return NodeModel{
+		URL:        m.URL,
+		Path:       m.Path,
+		Value_json: m.Value_json,
+		ETag:       m.ETag,
+	}
+}
+
 func (d *NodeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_node"
 }
@@ -35,12 +60,32 @@ func (d *NodeDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 
 		Attributes: map[string]schema.Attribute{
 			"url": schema.StringAttribute{
-				MarkdownDescription: "Specifies the HTTP address of URL of the bosk node.",
-				Required:            true,
+				MarkdownDescription: "Specifies the HTTP address of URL of the bosk node. Exactly one of `url` and `path` must be specified.",
+				Optional:            true,
+				Computed:            true,
+				Validators:          []validator.String{httpURLValidator{}},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Specifies the location of the bosk node relative to the provider's `base_url`. Exactly one of `url` and `path` must be specified.",
+				Optional:            true,
 			},
 			"value_json": schema.StringAttribute{
 				MarkdownDescription: "The JSON-encoded contents of the node",
-				Required:            true,
+				CustomType:          JSONType{},
+				Computed:            true,
+			},
+			"pointer": schema.StringAttribute{
+				MarkdownDescription: "An [RFC 6901](https://www.rfc-editor.org/rfc/rfc6901) JSON Pointer, such as `/world/id`, selecting the part of the node to return in `result_json`. Defaults to the empty pointer, which selects the whole node.",
+				Optional:            true,
+			},
+			"result_json": schema.StringAttribute{
+				MarkdownDescription: "The JSON-encoded value within the node selected by `pointer`.",
+				CustomType:          JSONType{},
+				Computed:            true,
+			},
+			"etag": schema.StringAttribute{
+				MarkdownDescription: "The revision of the node, as reported by the server. Null if the server doesn't report revisions.",
+				Computed:            true,
 			},
 		},
 	}
@@ -62,11 +107,34 @@ func (d *NodeDataSource) Configure(ctx context.Context, req datasource.Configure
 		return
 	}
 
-	d.client = client
+	d.client = client.withErrorAttributes(errorAttributes{address: path.Root("url")})
+}
+
+func (d *NodeDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data NodeDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node := data.node()
+	node.ValidateAddress(&resp.Diagnostics)
+
+	if !data.Pointer.IsNull() && !data.Pointer.IsUnknown() {
+		if _, err := parseJSONPointer(data.Pointer.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("pointer"), "Invalid JSON pointer", err.Error())
+		}
+	}
 }
 
 func (d *NodeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data NodeModel
+	if d.client == nil {
+		addUnconfiguredError(&resp.Diagnostics)
+		return
+	}
+
+	var data NodeDataSourceModel
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -74,12 +142,26 @@ func (d *NodeDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	result_json := d.client.GetJSONAsString(data.URL.ValueString(), &resp.Diagnostics)
+	node := data.node()
+	node.ResolveURL(d.client, &resp.Diagnostics)
+	node.Validate(&resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	data.Value_json = types.StringValue(result_json)
+	result_json, revision := d.client.GetJSONAsString(ctx, node.URL.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node.SetRevision(revision)
+	data.URL = node.URL
+	data.ETag = node.ETag
+	data.Value_json = NewJSONValue(result_json)
+	data.Result_json = NewJSONValue(selectJSON(result_json, data.Pointer.ValueString(), &resp.Diagnostics))
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Debug(ctx, "read bosk node datasource", map[string]interface{}{
 		"url": data.URL.ValueString(),
@@ -88,3 +170,26 @@ func (d *NodeDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// selectJSON returns the canonical JSON encoding of the value at the given pointer within the document.
+func selectJSON(document string, pointer string, diags *diag.Diagnostics) string {
+	if pointer == "" {
+		return document
+	}
+	parsed, err := decodeJSONLosslessly([]byte(document))
+	if err != nil {
+		diags.AddError("Node is not valid JSON", fmt.Sprintf("Unable to apply JSON pointer: %s", err))
+		return ""
+	}
+	selected, err := resolveJSONPointer(parsed, pointer)
+	if err != nil {
+		diags.AddAttributeError(path.Root("pointer"), "JSON pointer does not match", err.Error())
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := writeCanonicalJSON(&buf, selected); err != nil {
+		diags.AddError("Unable to encode selected value", err.Error())
+		return ""
+	}
+	return buf.String()
+}
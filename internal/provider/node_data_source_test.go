@@ -42,15 +42,110 @@ func TestAccNodeDataSource(t *testing.T) {
 						basic_auth_var_suffix = "NO_AUTH"
 					}
 					data "bosk_node" "test" {
-						url        = "%s%s"
-						value_json = jsonencode([])
+						url = "%s%s"
 					}
 				`, base, path),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.bosk_node.test", "url", base+path),
 					resource.TestCheckResourceAttr("data.bosk_node.test", "value_json", "[{\"world\":{\"id\":\"world\"}}]"),
+					resource.TestCheckResourceAttr("data.bosk_node.test", "result_json", "[{\"world\":{\"id\":\"world\"}}]"),
 				),
 			},
 		},
 	})
 }
+
+func TestAccNodeDataSourcePath(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+	fake.set("/bosk/path/to/object", `[{"world":{"id":"world"}}]`)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: fmt.Sprintf(`
+					provider "bosk" {
+						basic_auth_var_suffix = "NO_AUTH"
+						base_url              = "%s/bosk"
+					}
+					data "bosk_node" "test" {
+						path = "path/to/object"
+					}
+				`, testServer.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bosk_node.test", "url", testServer.URL+"/bosk/path/to/object"),
+					resource.TestCheckResourceAttr("data.bosk_node.test", "value_json", "[{\"world\":{\"id\":\"world\"}}]"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNodeDataSourcePointer(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+	fake.set("/bosk/object", `{"world":{"id":"world","tags":["a/b","c~d"],"big":9007199254740993}}`)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "bosk" {
+						basic_auth_var_suffix = "NO_AUTH"
+					}
+					data "bosk_node" "id" {
+						url     = "%[1]s/bosk/object"
+						pointer = "/world/id"
+					}
+					data "bosk_node" "tag" {
+						url     = "%[1]s/bosk/object"
+						pointer = "/world/tags/1"
+					}
+					data "bosk_node" "big" {
+						url     = "%[1]s/bosk/object"
+						pointer = "/world/big"
+					}
+				`, testServer.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bosk_node.id", "result_json", `"world"`),
+					resource.TestCheckResourceAttr("data.bosk_node.tag", "result_json", `"c~d"`),
+					resource.TestCheckResourceAttr("data.bosk_node.big", "result_json", `9007199254740993`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNodeDataSourceBearerAuth(t *testing.T) {
+	fake := newFakeBoskHandler(t)
+	fake.set("/bosk/object", `{"id":"object"}`)
+	testServer := httptest.NewServer(requireAuthorization(func(header string) bool { return header == "Bearer abc" }, fake))
+	defer testServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "bosk" {
+						auth {
+							bearer {
+								token = "abc"
+							}
+						}
+					}
+					data "bosk_node" "test" {
+						url = "%s/bosk/object"
+					}
+				`, testServer.URL),
+				Check: resource.TestCheckResourceAttr("data.bosk_node.test", "value_json", `{"id":"object"}`),
+			},
+		},
+	})
+}
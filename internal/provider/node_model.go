@@ -1,24 +1,385 @@
 package provider
 
 import (
+	"context"
 	"fmt"
-	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type NodeModel struct {
-	URL        types.String `tfsdk:"url"`
-	Value_json types.String `tfsdk:"value_json"`
+	URL        types.String   `tfsdk:"url"`
+	Path       types.String   `tfsdk:"path"`
+	Value_json JSONValue      `tfsdk:"value_json"`
+	ETag       types.String   `tfsdk:"etag"`
+	Move       types.Bool     `tfsdk:"move_on_url_change"`
+	OnDestroy  types.String   `tfsdk:"on_destroy"`
+	Destroy    JSONValue      `tfsdk:"destroy_value_json"`
+	Previous   JSONValue      `tfsdk:"previous_value_json"`
+	Excluded   types.List     `tfsdk:"excluded_child_paths"`
+	Ownership  types.String   `tfsdk:"ownership"`
+	Method     types.String   `tfsdk:"update_method"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Values of the on_destroy attribute
+const (
+	onDestroyDelete  = "delete"
+	onDestroyRetain  = "retain"
+	onDestroyRestore = "restore"
+	onDestroyPutJSON = "put_json"
+)
+
+var onDestroyModes = []string{onDestroyDelete, onDestroyRetain, onDestroyRestore, onDestroyPutJSON}
+
+// Values of the ownership attribute
+const (
+	ownershipFull           = "full"
+	ownershipDeclaredFields = "declared_fields"
+)
+
+// defaultOperationTimeout applies to operations with no corresponding setting in the timeouts block.
+const defaultOperationTimeout = 20 * time.Minute
+
+// nullTimeouts is the value of an omitted timeouts block.
+func nullTimeouts() timeouts.Value {
+	return nullTimeoutsFor("create", "read", "update", "delete")
+}
+
+// nullTimeoutsFor is the value of an omitted timeouts block supporting only the given operations.
+func nullTimeoutsFor(operations ...string) timeouts.Value {
+	attributeTypes := map[string]attr.Type{}
+	for _, operation := range operations {
+		attributeTypes[operation] = types.StringType
+	}
+	return timeouts.Value{
+		Object: types.ObjectNull(attributeTypes),
+	}
+}
+
+// ResolveURL fills in the URL field from the path field and the provider's base_url.
+// Nodes addressed directly by url are left alone.
+func (m *NodeModel) ResolveURL(client *BoskClient, diag *diag.Diagnostics) {
+	m.URL = resolveURL(client, m.URL, m.Path, path.Root("url"), path.Root("path"), diag)
+}
+
+// resolveURL returns the address given by pathValue relative to the provider's base_url,
+// or urlValue if there is no path.
+func resolveURL(client *BoskClient, urlValue types.String, pathValue types.String, urlAttribute path.Path, pathAttribute path.Path, diag *diag.Diagnostics) types.String {
+	if pathValue.IsNull() || pathValue.IsUnknown() {
+		return urlValue
+	}
+	if !client.HasBaseURL() {
+		diag.AddAttributeError(
+			pathAttribute,
+			"Provider base_url is required",
+			fmt.Sprintf("Path %q is relative, but the provider has no base_url; set base_url or the TF_BOSK_URL environment variable, or use %v instead of %v", pathValue.ValueString(), urlAttribute, pathAttribute),
+		)
+		return urlValue
+	}
+	return types.StringValue(client.URLForPath(pathValue.ValueString()))
+}
+
+// revision returns the ETag last observed for this node, or "" if the server didn't report one.
+func (m *NodeModel) revision() string {
+	return m.ETag.ValueString()
+}
+
+// SetRevision records the revision reported by the server, if any.
+func (m *NodeModel) SetRevision(revision string) {
+	if revision == "" {
+		m.ETag = types.StringNull()
+	} else {
+		m.ETag = types.StringValue(revision)
+	}
 }
 
 func (m *NodeModel) Validate(diag *diag.Diagnostics) {
-	var url string = m.URL.ValueString()
-	if !(strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://")) {
-		diag.AddError(
-			"URL must be http or https",
-			fmt.Sprintf("Expected url field to start with either \"http://\" or \"https://\". Got: %v", url),
+	if problem := checkHTTPURL(m.URL.ValueString()); problem != "" {
+		diag.AddAttributeError(path.Root("url"), "Invalid URL", problem)
+	}
+}
+
+// ValidateAddress checks that exactly one of url and path is set in the configuration.
+// Unknown values are assumed to be set, since they will be by apply time.
+func (m *NodeModel) ValidateAddress(diag *diag.Diagnostics) {
+	validateAddress(m.URL, m.Path, path.Root("url"), path.Root("path"), diag)
+}
+
+func validateAddress(urlValue types.String, pathValue types.String, urlAttribute path.Path, pathAttribute path.Path, diag *diag.Diagnostics) {
+	hasURL := !urlValue.IsNull()
+	hasPath := !pathValue.IsNull()
+	if hasURL && hasPath {
+		diag.AddAttributeError(
+			pathAttribute,
+			"Conflicting node address",
+			fmt.Sprintf("Only one of %v and %v may be specified", urlAttribute, pathAttribute),
+		)
+	} else if !hasURL && !hasPath {
+		diag.AddAttributeError(
+			urlAttribute,
+			"Missing node address",
+			fmt.Sprintf("One of %v or %v must be specified", urlAttribute, pathAttribute),
+		)
+	}
+}
+
+// onDestroy returns the configured on_destroy mode, or the default if there is none.
+func (m *NodeModel) onDestroy() string {
+	if m.OnDestroy.IsNull() {
+		return onDestroyDelete
+	}
+	return m.OnDestroy.ValueString()
+}
+
+// ValidateOnDestroy checks that on_destroy is a known mode,
+// and that destroy_value_json is given if and only if the mode calls for it.
+// Unknown values are skipped.
+func (m *NodeModel) ValidateOnDestroy(diag *diag.Diagnostics) {
+	if m.OnDestroy.IsUnknown() {
+		return
+	}
+	mode := m.onDestroy()
+	if !containsString(onDestroyModes, mode) {
+		diag.AddAttributeError(
+			path.Root("on_destroy"),
+			"Invalid on_destroy",
+			fmt.Sprintf("Expected one of %q, %q, %q or %q. Got: %q", onDestroyDelete, onDestroyRetain, onDestroyRestore, onDestroyPutJSON, mode),
+		)
+		return
+	}
+	if mode == onDestroyPutJSON && m.Destroy.IsNull() {
+		diag.AddAttributeError(
+			path.Root("destroy_value_json"),
+			"Missing destroy_value_json",
+			fmt.Sprintf("destroy_value_json is required when on_destroy is %q", onDestroyPutJSON),
+		)
+	} else if mode != onDestroyPutJSON && !m.Destroy.IsNull() {
+		diag.AddAttributeError(
+			path.Root("destroy_value_json"),
+			"Unexpected destroy_value_json",
+			fmt.Sprintf("destroy_value_json is only used when on_destroy is %q", onDestroyPutJSON),
+		)
+	}
+}
+
+// ownership returns the configured ownership mode, or the default if there is none.
+func (m *NodeModel) ownership() string {
+	if m.Ownership.IsNull() {
+		return ownershipFull
+	}
+	return m.Ownership.ValueString()
+}
+
+func (m *NodeModel) ownsDeclaredFieldsOnly() bool {
+	return m.ownership() == ownershipDeclaredFields
+}
+
+// ValidateOwnership checks that ownership is a known mode,
+// and that value_json is an object if only its fields are owned.
+// Unknown values are skipped.
+func (m *NodeModel) ValidateOwnership(diag *diag.Diagnostics) {
+	if m.Ownership.IsUnknown() {
+		return
+	}
+	mode := m.ownership()
+	if mode != ownershipFull && mode != ownershipDeclaredFields {
+		diag.AddAttributeError(
+			path.Root("ownership"),
+			"Invalid ownership",
+			fmt.Sprintf("Expected %q or %q. Got: %q", ownershipFull, ownershipDeclaredFields, mode),
 		)
+		return
+	}
+	if mode == ownershipDeclaredFields && !m.Value_json.IsNull() && !m.Value_json.IsUnknown() {
+		if _, ok := decodeJSONObject(m.Value_json.ValueString()); !ok {
+			diag.AddAttributeError(
+				path.Root("value_json"),
+				"Invalid value_json",
+				fmt.Sprintf("value_json must be a JSON object when ownership is %q", ownershipDeclaredFields),
+			)
+		}
+	}
+}
+
+// updateMethod returns the configured update_method, or the default if there is none.
+func (m *NodeModel) updateMethod() string {
+	if m.Method.IsNull() {
+		return updateMethodPut
+	}
+	return m.Method.ValueString()
+}
+
+// ValidateUpdateMethod checks that update_method is a known method.
+// Unknown values are skipped.
+func (m *NodeModel) ValidateUpdateMethod(diag *diag.Diagnostics) {
+	if m.Method.IsUnknown() {
+		return
+	}
+	if method := m.updateMethod(); !containsString(updateMethods, method) {
+		diag.AddAttributeError(
+			path.Root("update_method"),
+			"Invalid update_method",
+			fmt.Sprintf("Expected one of %q, %q or %q. Got: %q", updateMethodPut, updateMethodMergePatch, updateMethodJSONPatch, method),
+		)
+	}
+}
+
+// excludedChildPaths returns the unescaped segments of each of the excluded_child_paths.
+func (m *NodeModel) excludedChildPaths(diag *diag.Diagnostics) [][]string {
+	var result [][]string
+	for i, element := range m.Excluded.Elements() {
+		relative, ok := element.(types.String)
+		if !ok || relative.IsUnknown() {
+			continue
+		}
+		segments, err := parseRelativePath(relative.ValueString())
+		if err != nil {
+			diag.AddAttributeError(path.Root("excluded_child_paths").AtListIndex(i), "Invalid child path", err.Error())
+			continue
+		}
+		result = append(result, segments)
+	}
+	return result
+}
+
+// ValidateExcludedChildPaths reports any of the excluded_child_paths that can't be parsed.
+func (m *NodeModel) ValidateExcludedChildPaths(diag *diag.Diagnostics) {
+	m.excludedChildPaths(diag)
+}
+
+// ClaimNode registers the node, apart from its excluded children, with the client,
+// reporting any overlap with nodes managed by other resources.
+// Does nothing until the url and excluded_child_paths are known.
+func (m *NodeModel) ClaimNode(ctx context.Context, client *BoskClient, private privateState, diag *diag.Diagnostics) {
+	if m.URL.IsUnknown() || m.Excluded.IsUnknown() {
+		return
+	}
+	var excludedURLs []string
+	for _, segments := range m.excludedChildPaths(diag) {
+		excludedURLs = append(excludedURLs, descendantURL(m.url(), segments))
+	}
+	client.ClaimNode(ctx, private, m.url(), excludedURLs, path.Root("url"), diag)
+}
+
+// needsLiveValue reports whether writing the node depends on its live value,
+// because the resource doesn't own all of it.
+func (m *NodeModel) needsLiveValue() bool {
+	return len(m.Excluded.Elements()) > 0 || m.ownsDeclaredFieldsOnly()
+}
+
+// valueToPut returns the value to write for the node, given its live contents, and the revision to make the write conditional on.
+// Live values of fields and children the resource doesn't own are preserved, in which case the write is
+// conditional on the live revision, since their owners change the node's revision without this resource's knowledge.
+func (m *NodeModel) valueToPut(live NodeContents, revision string, diag *diag.Diagnostics) (string, string) {
+	excluded := m.excludedChildPaths(diag)
+	if !m.needsLiveValue() || !live.Found {
+		return m.Value_json.ValueString(), revision
+	}
+	value := m.Value_json.ValueString()
+	var err error
+	if m.ownsDeclaredFieldsOnly() {
+		value, err = mergeDeclaredFields(live.JSON, value)
+	}
+	if err == nil {
+		value, err = spliceExcluded(value, live.JSON, excluded, true)
+	}
+	if err != nil {
+		diag.AddError("Unable to preserve fields owned elsewhere", err.Error())
+		return "", ""
+	}
+	return value, live.Revision
+}
+
+// ownedValue returns the part of the node's live value that the resource owns, for comparison with its configuration.
+// Fields and children the resource doesn't own keep their values from the prior state.
+func (m *NodeModel) ownedValue(liveJSON string, diag *diag.Diagnostics) string {
+	prior := m.Value_json.ValueString()
+	value := liveJSON
+	var err error
+	if m.ownsDeclaredFieldsOnly() {
+		value, err = declaredFieldsOf(liveJSON, prior)
+	}
+	if err == nil {
+		value, err = spliceExcluded(value, prior, m.excludedChildPaths(diag), false)
+	}
+	if err != nil {
+		diag.AddError("Unable to ignore fields owned elsewhere", err.Error())
+		return ""
+	}
+	return value
+}
+
+// decodeJSONObject decodes the given JSON, reporting whether it is an object.
+func decodeJSONObject(objectJSON string) (map[string]interface{}, bool) {
+	value, err := decodeJSONLosslessly([]byte(objectJSON))
+	if err != nil {
+		return nil, false
+	}
+	object, ok := value.(map[string]interface{})
+	return object, ok
+}
+
+// mergeDeclaredFields returns the live object with the declared fields overwritten.
+// If the live value isn't an object, there is nothing to preserve, and the declared object is returned as is.
+func mergeDeclaredFields(liveJSON string, declaredJSON string) (string, error) {
+	declared, ok := decodeJSONObject(declaredJSON)
+	if !ok {
+		return "", fmt.Errorf("expected a JSON object. Got: %v", declaredJSON)
+	}
+	live, ok := decodeJSONObject(liveJSON)
+	if !ok {
+		return declaredJSON, nil
+	}
+	for name, value := range declared {
+		live[name] = value
+	}
+	return encodeJSON(live)
+}
+
+// declaredFieldsOf returns the fields of the live object that also appear in the declared one.
+// If either isn't an object, the live value is returned as is, so the difference shows up as drift.
+func declaredFieldsOf(liveJSON string, declaredJSON string) (string, error) {
+	declared, ok := decodeJSONObject(declaredJSON)
+	if !ok {
+		return liveJSON, nil
+	}
+	live, ok := decodeJSONObject(liveJSON)
+	if !ok {
+		return liveJSON, nil
+	}
+	result := make(map[string]interface{}, len(declared))
+	for name := range declared {
+		if value, found := live[name]; found {
+			result[name] = value
+		}
+	}
+	return encodeJSON(result)
+}
+
+// spliceExcluded returns valueJSON with each of the excluded children replaced by its value in sourceJSON,
+// or removed if sourceJSON has none. If keepMissing is true, children missing from sourceJSON are left alone instead.
+func spliceExcluded(valueJSON string, sourceJSON string, excluded [][]string, keepMissing bool) (string, error) {
+	if len(excluded) == 0 {
+		return valueJSON, nil
+	}
+	value, err := decodeJSONLosslessly([]byte(valueJSON))
+	if err != nil {
+		return "", err
+	}
+	source, err := decodeJSONLosslessly([]byte(sourceJSON))
+	if err != nil {
+		return "", err
+	}
+	for _, segments := range excluded {
+		if _, found := boskDescendant(source, segments); found || !keepMissing {
+			value = spliceDescendant(value, source, true, segments)
+		}
 	}
+	return encodeJSON(value)
 }
@@ -6,9 +6,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -16,6 +21,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &NodeResource{}
 var _ resource.ResourceWithImportState = &NodeResource{}
+var _ resource.ResourceWithValidateConfig = &NodeResource{}
+var _ resource.ResourceWithModifyPlan = &NodeResource{}
 
 func NewNodeResource() resource.Resource {
 	return &NodeResource{}
@@ -36,13 +43,84 @@ func (r *NodeResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 
 		Attributes: map[string]schema.Attribute{
 			"url": schema.StringAttribute{
-				MarkdownDescription: "Specifies the HTTP address of URL of the bosk node.",
-				Required:            true,
+				MarkdownDescription: "Specifies the HTTP address of URL of the bosk node. Exactly one of `url` and `path` must be specified. Changing it replaces the node unless `move_on_url_change` is true.",
+				Optional:            true,
+				Computed:            true,
+				Validators:          []validator.String{httpURLValidator{}},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Specifies the location of the bosk node relative to the provider's `base_url`. Exactly one of `url` and `path` must be specified.",
+				Optional:            true,
 			},
 			"value_json": schema.StringAttribute{
 				MarkdownDescription: "The JSON-encoded contents of the node",
+				CustomType:          JSONType{},
 				Required:            true,
+				Validators:          []validator.String{jsonValidator{}},
+			},
+			"move_on_url_change": schema.BoolAttribute{
+				MarkdownDescription: "By default, changing the node's address (its `url`, or the `url` resolved from its `path`) replaces the resource: the old node is destroyed (see `on_destroy`) before the new one is created. If true, the node is instead moved: its value is written to the new address first, and only then is the old node destroyed, so there is no moment when neither exists.",
+				Optional:            true,
+			},
+			"on_destroy": schema.StringAttribute{
+				MarkdownDescription: "What to do with the node when the resource is destroyed or replaced. " +
+					"`delete` (the default) deletes it, which bosk only permits for `Optional` fields and catalog, listing and side table entries. " +
+					"`retain` leaves the node as it is. " +
+					"`restore` puts back `previous_value_json`, or deletes the node if it didn't exist before. " +
+					"`put_json` puts `destroy_value_json`. " +
+					"As with any attribute, a change takes effect only once it has been applied.",
+				Optional: true,
+			},
+			"destroy_value_json": schema.StringAttribute{
+				MarkdownDescription: "The JSON-encoded value to put when the resource is destroyed. Required when `on_destroy` is `put_json`, and not allowed otherwise.",
+				CustomType:          JSONType{},
+				Optional:            true,
+				Validators:          []validator.String{jsonValidator{}},
+			},
+			"previous_value_json": schema.StringAttribute{
+				MarkdownDescription: "The JSON-encoded contents of the node before this resource first wrote to it, or null if it didn't exist. For imported nodes, this is the value at the time of import. Used when `on_destroy` is `restore`.",
+				CustomType:          JSONType{},
+				Computed:            true,
+			},
+			"etag": schema.StringAttribute{
+				MarkdownDescription: "The revision of the node as last observed from the server, sent in `If-Match` to detect concurrent changes. Null if the server doesn't report revisions.",
+				Computed:            true,
+			},
+			"ownership": schema.StringAttribute{
+				MarkdownDescription: "How much of the node this resource manages. " +
+					"`full` (the default) manages the whole value: fields missing from `value_json` are removed, and added fields show up as drift. " +
+					"`declared_fields` manages only the top-level fields that appear in `value_json`, which must then be an object: " +
+					"other fields, such as defaults filled in by the server or fields owned by other systems, are ignored when comparing, " +
+					"and writes read the node and merge the declared fields into it, conditional on it not changing in the meantime. " +
+					"Fields removed from `value_json` are left as they are.",
+				Optional: true,
+			},
+			"excluded_child_paths": schema.ListAttribute{
+				MarkdownDescription: "Paths, relative to this node, of descendants managed by other resources, like `worlds/earth` for the entry `earth` in the catalog field `worlds`. " +
+					"Their live values are kept when this node is written and ignored when it is compared, so the resources don't undo each other's changes. " +
+					"Since those other resources change this node's revision, writes, including those made when the resource is destroyed, are conditional on a fresh read rather than on `etag`.",
+				ElementType: types.StringType,
+				Optional:    true,
 			},
+			"update_method": schema.StringAttribute{
+				MarkdownDescription: "How changes to an existing node are sent. " +
+					"`put` (the default) PUTs the entire value. " +
+					"`merge_patch` sends an [RFC 7396](https://www.rfc-editor.org/rfc/rfc7396) merge patch, and `json_patch` an [RFC 6902](https://www.rfc-editor.org/rfc/rfc6902) JSON Patch, " +
+					"describing just the difference from the value last read, so large nodes don't need to be sent whole for a small change. " +
+					"Either way, the write is conditional on the node's revision. " +
+					"The value is PUT instead when the node has moved, when a merge patch can't express the change because it sets something to null, " +
+					"or when the server responds 405 Method Not Allowed.",
+				Optional: true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -64,14 +142,85 @@ func (r *NodeResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	r.client = client
+	r.client = client.withErrorAttributes(errorAttributes{value: path.Root("value_json"), address: path.Root("url")})
 }
 
 func (r NodeModel) url() string {
 	return r.URL.ValueString()
 }
 
+func (r *NodeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NodeModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ValidateAddress(&resp.Diagnostics)
+	data.ValidateOnDestroy(&resp.Diagnostics)
+	data.ValidateExcludedChildPaths(&resp.Diagnostics)
+	data.ValidateOwnership(&resp.Diagnostics)
+	data.ValidateUpdateMethod(&resp.Diagnostics)
+}
+
+func (r *NodeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy, or if the provider has not been configured yet.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var data NodeModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Resolve path-based nodes now so the plan shows the actual url
+	data.ResolveURL(r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ClaimNode(ctx, r.client, resp.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+		return
+	}
+	var state NodeModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The previous value is captured only when the node is first written,
+	// which includes writing it to a new url when it's moved.
+	// An unknown url might turn out to be different, so it gets the same treatment.
+	urlChanged := !data.URL.Equal(state.URL)
+	if urlChanged && data.Move.ValueBool() {
+		data.Previous = NewJSONUnknown()
+	} else {
+		data.Previous = state.Previous
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+
+	// Writing to a new url would leave the old node orphaned on the server,
+	// so a new address means a new node unless the user has opted to move it.
+	if urlChanged && !data.Move.ValueBool() {
+		resp.RequiresReplace.Append(path.Root("url"))
+	}
+}
+
 func (r *NodeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		addUnconfiguredError(&resp.Diagnostics)
+		return
+	}
+
 	var data NodeModel
 
 	// Read Terraform plan data into the model
@@ -81,17 +230,38 @@ func (r *NodeResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	data.ResolveURL(r.client, &resp.Diagnostics)
 	data.Validate(&resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		tflog.Warn(ctx, "Invalid plan", map[string]interface{}{"diagnostics": resp.Diagnostics})
 		return
 	}
 
-	r.client.PutJSONAsString(data.url(), data.Value_json.ValueString(), &resp.Diagnostics)
+	live := r.client.GetNode(ctx, data.url(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		tflog.Warn(ctx, "Error performing GET", map[string]interface{}{"diagnostics": resp.Diagnostics})
+		return
+	}
+	data.Previous = previousValue(live)
+
+	value, expectedRevision := data.valueToPut(live, "", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	revision := r.client.PutJSONAsString(ctx, data.url(), value, expectedRevision, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		tflog.Warn(ctx, "Error performing PUT", map[string]interface{}{"diagnostics": resp.Diagnostics})
 		return
 	}
+	data.SetRevision(revision)
 
 	tflog.Debug(ctx, "created bosk node", map[string]interface{}{
 		"url": data.url(),
@@ -102,6 +272,11 @@ func (r *NodeResource) Create(ctx context.Context, req resource.CreateRequest, r
 }
 
 func (r *NodeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// While the provider defers its configuration, keep the prior state
+	if r.client == nil {
+		return
+	}
+
 	var data NodeModel
 
 	// Read Terraform configuration data into the model
@@ -110,19 +285,41 @@ func (r *NodeResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		tflog.Warn(ctx, "Error getting plan data", map[string]interface{}{"diagnostics": resp.Diagnostics})
 		return
 	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	data.Validate(&resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		tflog.Warn(ctx, "Invalid state", map[string]interface{}{"diagnostics": resp.Diagnostics})
 		return
 	}
 
-	result_json := r.client.GetJSONAsString(data.url(), &resp.Diagnostics)
+	contents := r.client.GetNode(ctx, data.url(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		tflog.Warn(ctx, "Error performing GET", map[string]interface{}{"diagnostics": resp.Diagnostics})
 		return
 	}
+	if !contents.Found {
+		// Deleted out of band; let Terraform plan to re-create it
+		tflog.Info(ctx, "bosk node not found; removing from state", map[string]interface{}{
+			"url": data.url(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
-	data.Value_json = types.StringValue(result_json)
+	valueJSON := data.ownedValue(contents.JSON, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Value_json = NewJSONValue(valueJSON)
+	data.SetRevision(contents.Revision)
 
 	tflog.Debug(ctx, "read bosk node", map[string]interface{}{
 		"url": data.url(),
@@ -137,23 +334,65 @@ func (r *NodeResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *NodeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client == nil {
+		addUnconfiguredError(&resp.Diagnostics)
+		return
+	}
+
 	var data NodeModel
+	var state NodeModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	data.ResolveURL(r.client, &resp.Diagnostics)
 	data.Validate(&resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		tflog.Warn(ctx, "Invalid plan", map[string]interface{}{"diagnostics": resp.Diagnostics})
 		return
 	}
 
-	r.client.PutJSONAsString(data.url(), data.Value_json.ValueString(), &resp.Diagnostics)
+	// Only the node we last read is subject to the If-Match check
+	moved := state.url() != data.url()
+	var live NodeContents
+	var expectedRevision string
+	if moved {
+		live = r.client.GetNode(ctx, data.url(), &resp.Diagnostics)
+		data.Previous = previousValue(live)
+	} else {
+		expectedRevision = state.revision()
+		if data.needsLiveValue() {
+			live = r.client.GetNode(ctx, data.url(), &resp.Diagnostics)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	value, expectedRevision := data.valueToPut(live, expectedRevision, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	base := state.Value_json.ValueString()
+	if live.Found {
+		base = live.JSON
+	}
+	revision := r.write(ctx, data, moved, base, value, expectedRevision, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.SetRevision(revision)
 
 	tflog.Debug(ctx, "updated bosk node", map[string]interface{}{
 		"url": data.url(),
@@ -161,47 +400,171 @@ func (r *NodeResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() || !moved {
+		return
+	}
+
+	// ModifyPlan only allows the url to change in move_on_url_change mode.
+	// The node now lives at its new url, so failing to clean up the old one
+	// doesn't change the state; it just needs attention.
+	var releaseDiags diag.Diagnostics
+	r.release(ctx, state, &releaseDiags)
+	if releaseDiags.HasError() {
+		for _, d := range releaseDiags.Errors() {
+			resp.Diagnostics.AddError(
+				"Unable to clean up moved node",
+				fmt.Sprintf("The node was written to %v, but the old node at %v could not be cleaned up according to on_destroy = %q and must be dealt with manually.\n\n%v", data.url(), state.url(), state.onDestroy(), d.Detail()),
+			)
+		}
+		return
+	}
+
+	tflog.Debug(ctx, "moved bosk node", map[string]interface{}{
+		"from": state.url(),
+		"to":   data.url(),
+	})
+}
+
+// write changes the node from the base value to the given value according to its update_method,
+// falling back to PUT if a patch is unsuitable or unsupported. Returns the node's new revision, if any.
+func (r *NodeResource) write(ctx context.Context, data NodeModel, moved bool, base string, value string, revision string, diag *diag.Diagnostics) string {
+	method := data.updateMethod()
+	if method != updateMethodPut && !moved {
+		patch, contentType, ok, err := patchDocument(method, base, value)
+		if err != nil {
+			diag.AddError("Unable to compute patch", err.Error())
+			return ""
+		}
+		if ok {
+			newRevision, supported := r.client.PatchJSONAsString(ctx, data.url(), patch, contentType, revision, diag)
+			if supported {
+				return newRevision
+			}
+			tflog.Warn(ctx, "Server does not support PATCH; falling back to PUT", map[string]interface{}{
+				"url":           data.url(),
+				"update_method": method,
+			})
+		} else {
+			tflog.Debug(ctx, "Patch cannot express the change; falling back to PUT", map[string]interface{}{
+				"url":           data.url(),
+				"update_method": method,
+			})
+		}
+	}
+	return r.client.PutJSONAsString(ctx, data.url(), value, revision, diag)
 }
 
 func (r *NodeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		addUnconfiguredError(&resp.Diagnostics)
+		return
+	}
+
 	var data NodeModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	data.Validate(&resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		tflog.Warn(ctx, "Invalid state", map[string]interface{}{"diagnostics": resp.Diagnostics})
 		return
 	}
 
-	r.client.Delete(data.url(), &resp.Diagnostics)
+	r.release(ctx, data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Debug(ctx, "deleted bosk node", map[string]interface{}{
-		"url": data.url(),
-	})
-
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (r *NodeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	url := req.ID
+// previousValue returns the value of a node read before the resource first writes to it,
+// or null if there was no node there yet.
+func previousValue(contents NodeContents) JSONValue {
+	if !contents.Found {
+		return NewJSONNull()
+	}
+	return NewJSONValue(contents.JSON)
+}
 
-	result_json := r.client.GetJSONAsString(url, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
+// release gives up the resource's control of the node described by data, according to its on_destroy mode.
+func (r *NodeResource) release(ctx context.Context, data NodeModel, diag *diag.Diagnostics) {
+	mode := data.onDestroy()
+	revision := data.revision()
+	if mode != onDestroyRetain && data.needsLiveValue() {
+		// Other owners change the node's revision, so etag may be stale
+		revision = r.client.GetNode(ctx, data.url(), diag).Revision
+		if diag.HasError() {
+			return
+		}
+	}
+	switch {
+	case mode == onDestroyRetain:
+		// Nothing to do
+	case mode == onDestroyPutJSON:
+		r.client.PutJSONAsString(ctx, data.url(), data.Destroy.ValueString(), revision, diag)
+	case mode == onDestroyRestore && !data.Previous.IsNull():
+		r.client.PutJSONAsString(ctx, data.url(), data.Previous.ValueString(), revision, diag)
+	default:
+		// Delete, or restore a node that didn't previously exist
+		r.client.Delete(ctx, data.url(), revision, diag)
+	}
+	if diag.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "released bosk node", map[string]interface{}{
+		"url":        data.url(),
+		"on_destroy": mode,
+	})
+}
+
+// ImportState accepts either an absolute url, or a path relative to the provider's base_url.
+func (r *NodeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.client == nil {
+		addUnconfiguredError(&resp.Diagnostics)
 		return
 	}
 
 	data := NodeModel{
-		URL:        types.StringValue(url),
-		Value_json: types.StringValue(result_json),
+		URL:       types.StringNull(),
+		Path:      types.StringNull(),
+		Excluded:  types.ListNull(types.StringType),
+		Ownership: types.StringNull(),
+		Method:    types.StringNull(),
+		Timeouts:  nullTimeouts(),
+	}
+	if strings.HasPrefix(req.ID, "http://") || strings.HasPrefix(req.ID, "https://") {
+		data.URL = types.StringValue(req.ID)
+	} else {
+		data.Path = types.StringValue(req.ID)
+		data.ResolveURL(r.client, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	result_json, revision := r.client.GetJSONAsString(ctx, data.url(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	data.Value_json = NewJSONValue(result_json)
+	data.Previous = NewJSONValue(result_json)
+	data.SetRevision(revision)
+
 	tflog.Debug(ctx, "imported bosk node", map[string]interface{}{
 		"url": data.url(),
 	})
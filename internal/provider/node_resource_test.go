@@ -9,11 +9,15 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
 func TestAccNodeResource(t *testing.T) {
@@ -76,6 +80,8 @@ func TestAccNodeResource(t *testing.T) {
 				ImportStateVerify:                    true,
 				ImportStateVerifyIdentifierAttribute: "url",
 				ImportStateId:                        base + path,
+				// Import captures the node's current value, but creation found no previous value
+				ImportStateVerifyIgnore: []string{"previous_value_json"},
 			},
 			// // Update and Read testing
 			{
@@ -107,3 +113,656 @@ func testAccNodeResourceConfig(base, path string, value any) string {
 		}
 	`, base, path, strconv.Quote(string(json)))
 }
+
+func TestAccNodeResourcePath(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+
+	base := testServer.URL + "/bosk/"
+	path := "/path/to/object"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccNodeResourcePathConfig(base, path, []map[string]map[string]string{
+					{"world": {"id": "world"}},
+				}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bosk_node.test", "url", testServer.URL+"/bosk/path/to/object"),
+					resource.TestCheckResourceAttr("bosk_node.test", "path", path),
+					resource.TestCheckResourceAttrSet("bosk_node.test", "etag"),
+					resource.TestCheckResourceAttr("bosk_node.test", "value_json", "[{\"world\":{\"id\":\"world\"}}]"),
+					func(*terraform.State) error {
+						if _, ok := fake.get("/bosk/path/to/object"); !ok {
+							return fmt.Errorf("node was not written to the resolved url")
+						}
+						return nil
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "bosk_node.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "url",
+				ImportStateId:                        path,
+				// Import captures the node's current value, but creation found no previous value
+				ImportStateVerifyIgnore: []string{"previous_value_json"},
+			},
+			// Out-of-band deletion is planned as re-creation
+			{
+				PreConfig: func() {
+					fake.remove("/bosk/path/to/object")
+				},
+				Config: testAccNodeResourcePathConfig(base, path, []map[string]map[string]string{
+					{"world": {"id": "world"}},
+				}),
+				Check: func(*terraform.State) error {
+					if _, ok := fake.get("/bosk/path/to/object"); !ok {
+						return fmt.Errorf("node was not re-created")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccNodeResourcePathConfig(base, path string, value any) string {
+	json, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf(`
+		provider "bosk" {
+			basic_auth_var_suffix = "NO_AUTH"
+			base_url              = "%s"
+		}
+		resource "bosk_node" "test" {
+			path       = "%s"
+			value_json = %s
+		}
+	`, base, path, strconv.Quote(string(json)))
+}
+
+func TestAccNodeResourceSemanticEquality(t *testing.T) {
+	_, testServer := newFakeBosk(t)
+	defer testServer.Close()
+
+	// Unsorted keys, extra whitespace, and characters that encoding/json escapes
+	config := fmt.Sprintf(`
+		provider "bosk" {
+			basic_auth_var_suffix = "NO_AUTH"
+		}
+		resource "bosk_node" "test" {
+			url        = "%s/bosk/object"
+			value_json = <<-EOT
+				{ "world": { "id": "world", "description": "<&>" }, "catalog": [] }
+			EOT
+		}
+	`, testServer.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// Refreshing the normalized server value must not produce a diff
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccNodeResourceTimeout(t *testing.T) {
+	testServer := hangingServer()
+	defer testServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "bosk" {
+						basic_auth_var_suffix = "NO_AUTH"
+						max_retries           = 0
+					}
+					resource "bosk_node" "test" {
+						url        = "%s/bosk/object"
+						value_json = jsonencode({})
+						timeouts {
+							create = "100ms"
+						}
+					}
+				`, testServer.URL),
+				ExpectError: regexp.MustCompile("did not complete"),
+			},
+		},
+	})
+}
+
+func TestAccNodeResourceURLChange(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNodeResourceMoveConfig(testServer.URL+"/bosk/old", false),
+			},
+			// By default, a new url replaces the node
+			{
+				Config: testAccNodeResourceMoveConfig(testServer.URL+"/bosk/new", false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bosk_node.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: func(*terraform.State) error {
+					if _, ok := fake.get("/bosk/old"); ok {
+						return fmt.Errorf("old node was left behind")
+					}
+					if _, ok := fake.get("/bosk/new"); !ok {
+						return fmt.Errorf("new node was not created")
+					}
+					return nil
+				},
+			},
+			// With move_on_url_change, a new url is an in-place update
+			{
+				Config: testAccNodeResourceMoveConfig(testServer.URL+"/bosk/new", true),
+			},
+			{
+				Config: testAccNodeResourceMoveConfig(testServer.URL+"/bosk/moved", true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bosk_node.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bosk_node.test", "url", testServer.URL+"/bosk/moved"),
+					func(*terraform.State) error {
+						if _, ok := fake.get("/bosk/new"); ok {
+							return fmt.Errorf("old node was left behind")
+						}
+						if _, ok := fake.get("/bosk/moved"); !ok {
+							return fmt.Errorf("node was not moved")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccNodeResourceMoveOntoExistingNode(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+
+	config := func(url string) string {
+		return fmt.Sprintf(`
+			provider "bosk" {
+				basic_auth_var_suffix = "NO_AUTH"
+			}
+			resource "bosk_node" "test" {
+				url                = "%s"
+				value_json         = jsonencode({ id = "node" })
+				move_on_url_change = true
+				on_destroy         = "restore"
+			}
+		`, url)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(testServer.URL + "/bosk/old"),
+				Check:  resource.TestCheckNoResourceAttr("bosk_node.test", "previous_value_json"),
+			},
+			// The move captures the value it overwrites at the new url
+			{
+				PreConfig: func() {
+					fake.set("/bosk/new", `{"id":"existing"}`)
+				},
+				Config: config(testServer.URL + "/bosk/new"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bosk_node.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("bosk_node.test", tfjsonpath.New("previous_value_json")),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("bosk_node.test", "previous_value_json", `{"id":"existing"}`),
+			},
+		},
+		// Destroying restores what was at the new url before the move, and the old url was already cleaned up
+		CheckDestroy: func(*terraform.State) error {
+			if actual, _ := fake.get("/bosk/new"); !jsonEquivalent(actual, `{"id":"existing"}`) {
+				return fmt.Errorf("expected the existing node to be restored; found %q", actual)
+			}
+			if actual, ok := fake.get("/bosk/old"); ok {
+				return fmt.Errorf("expected the old node to be deleted; found %s", actual)
+			}
+			return nil
+		},
+	})
+}
+
+func testAccNodeResourceMoveConfig(url string, move bool) string {
+	return fmt.Sprintf(`
+		provider "bosk" {
+			basic_auth_var_suffix = "NO_AUTH"
+		}
+		resource "bosk_node" "test" {
+			url                = "%s"
+			value_json         = jsonencode({ id = "node" })
+			move_on_url_change = %t
+		}
+	`, url, move)
+}
+
+func TestAccNodeResourceOnDestroy(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string // The node's value before Terraform touches it; empty if absent
+		settings string
+		expected string // The node's value after destroy; empty if absent
+	}{
+		{"delete", `{"id":"before"}`, ``, ``},
+		{"retain", `{"id":"before"}`, `on_destroy = "retain"`, `{"id":"managed"}`},
+		{"restore", `{"id":"before"}`, `on_destroy = "restore"`, `{"id":"before"}`},
+		{"restore absent", ``, `on_destroy = "restore"`, ``},
+		{"put_json", `{"id":"before"}`, `
+			on_destroy         = "put_json"
+			destroy_value_json = jsonencode({ id = "reset" })`, `{"id":"reset"}`},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			fake, testServer := newFakeBosk(t)
+			defer testServer.Close()
+			if test.existing != "" {
+				fake.set("/bosk/node", test.existing)
+			}
+
+			config := fmt.Sprintf(`
+				provider "bosk" {
+					basic_auth_var_suffix = "NO_AUTH"
+				}
+				resource "bosk_node" "test" {
+					url        = "%s/bosk/node"
+					value_json = jsonencode({ id = "managed" })
+					%s
+				}
+			`, testServer.URL, test.settings)
+
+			var previousCheck resource.TestCheckFunc
+			if test.existing == "" {
+				previousCheck = resource.TestCheckNoResourceAttr("bosk_node.test", "previous_value_json")
+			} else {
+				previousCheck = resource.TestCheckResourceAttr("bosk_node.test", "previous_value_json", test.existing)
+			}
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  previousCheck,
+					},
+				},
+				CheckDestroy: func(*terraform.State) error {
+					actual, found := fake.get("/bosk/node")
+					if test.expected == "" {
+						if found {
+							return fmt.Errorf("expected node to be absent after destroy; found %s", actual)
+						}
+						return nil
+					}
+					if !found || !jsonEquivalent(actual, test.expected) {
+						return fmt.Errorf("expected node to be %s after destroy; found %q", test.expected, actual)
+					}
+					return nil
+				},
+			})
+		})
+	}
+}
+
+func TestAccNodeResourceOnDestroyWithExcludedChild(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+	fake.enableNestedRevisions()
+
+	// The child is destroyed first, changing the parent's revision before the parent is released
+	config := fmt.Sprintf(`
+		provider "bosk" {
+			basic_auth_var_suffix = "NO_AUTH"
+		}
+		resource "bosk_node" "parent" {
+			url                  = "%s/bosk/node"
+			value_json           = jsonencode({ id = "managed" })
+			excluded_child_paths = ["child"]
+			on_destroy           = "put_json"
+			destroy_value_json   = jsonencode({ id = "reset" })
+		}
+		resource "bosk_node" "child" {
+			url        = "${bosk_node.parent.url}/child"
+			value_json = jsonencode({ id = "child" })
+		}
+	`, testServer.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			if actual, _ := fake.get("/bosk/node"); !jsonEquivalent(actual, `{"id":"reset"}`) {
+				return fmt.Errorf("expected the parent to be reset after destroy; found %q", actual)
+			}
+			if _, found := fake.get("/bosk/node/child"); found {
+				return fmt.Errorf("expected the child to be deleted")
+			}
+			return nil
+		},
+	})
+}
+
+func TestAccNodeResourceOnDestroyValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "bosk" {
+						basic_auth_var_suffix = "NO_AUTH"
+					}
+					resource "bosk_node" "test" {
+						url        = "http://localhost:1740/bosk/node"
+						value_json = "{}"
+						on_destroy = "put_json"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`destroy_value_json is required`),
+			},
+			{
+				Config: `
+					provider "bosk" {
+						basic_auth_var_suffix = "NO_AUTH"
+					}
+					resource "bosk_node" "test" {
+						url        = "http://localhost:1740/bosk/node"
+						value_json = "{}"
+						on_destroy = "forget"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid on_destroy`),
+			},
+		},
+	})
+}
+
+func TestAccNodeResourceValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "bosk" {
+						basic_auth_var_suffix = "NO_AUTH"
+					}
+					resource "bosk_node" "test" {
+						url        = "localhost:1740/bosk/node"
+						value_json = "{}"
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid URL`),
+			},
+			{
+				Config: `
+					provider "bosk" {
+						basic_auth_var_suffix = "NO_AUTH"
+					}
+					resource "bosk_node" "test" {
+						url        = "http://localhost:1740/bosk/node"
+						value_json = "{\"id\": \"node\",}"
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`line 1, column 16`),
+			},
+		},
+	})
+}
+
+func TestAccNodeResourceOverlap(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "bosk" {
+						basic_auth_var_suffix = "NO_AUTH"
+						base_url              = "http://localhost:1740/bosk"
+					}
+					resource "bosk_node" "parent" {
+						path       = "a"
+						value_json = jsonencode({ b = { c = 1 } })
+					}
+					resource "bosk_node" "child" {
+						path       = "a/b"
+						value_json = jsonencode({ c = 2 })
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Overlapping bosk nodes`),
+			},
+			{
+				Config: `
+					provider "bosk" {
+						basic_auth_var_suffix = "NO_AUTH"
+						base_url              = "http://localhost:1740/bosk"
+					}
+					resource "bosk_node" "first" {
+						path       = "a"
+						value_json = jsonencode({})
+					}
+					resource "bosk_node" "second" {
+						url        = "http://localhost:1740/bosk/a"
+						value_json = jsonencode({})
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`also managed by another resource`),
+			},
+			{
+				Config: `
+					provider "bosk" {
+						basic_auth_var_suffix = "NO_AUTH"
+						base_url              = "http://localhost:1740/bosk"
+					}
+					resource "bosk_node" "parent" {
+						path                 = "a"
+						value_json           = jsonencode({ b = { c = 1 } })
+						excluded_child_paths = ["b"]
+					}
+					resource "bosk_node" "child" {
+						path       = "a/b"
+						value_json = jsonencode({ c = 2 })
+					}
+				`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccNodeResourceDeclaredFields(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+	fake.set("/bosk/node", `{"id":"node","owner":"someone else"}`)
+
+	config := fmt.Sprintf(`
+		provider "bosk" {
+			basic_auth_var_suffix = "NO_AUTH"
+		}
+		resource "bosk_node" "test" {
+			url        = "%s/bosk/node"
+			value_json = jsonencode({ name = "managed" })
+			ownership  = "declared_fields"
+		}
+	`, testServer.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bosk_node.test", "value_json", `{"name":"managed"}`),
+					func(*terraform.State) error {
+						actual, _ := fake.get("/bosk/node")
+						if !jsonEquivalent(actual, `{"id":"node","name":"managed","owner":"someone else"}`) {
+							return fmt.Errorf("expected undeclared fields to be preserved; got %s", actual)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// Fields filled in by the server are not drift
+				PreConfig: func() {
+					fake.set("/bosk/node", `{"id":"node","name":"managed","owner":"someone else","default":true}`)
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				Config: fmt.Sprintf(`
+					provider "bosk" {
+						basic_auth_var_suffix = "NO_AUTH"
+					}
+					resource "bosk_node" "test" {
+						url        = "%s/bosk/node"
+						value_json = jsonencode(["not", "an", "object"])
+						ownership  = "declared_fields"
+					}
+				`, testServer.URL),
+				ExpectError: regexp.MustCompile(`value_json must be a JSON object`),
+			},
+		},
+	})
+}
+
+func TestAccNodeResourceUpdateMethod(t *testing.T) {
+	// Either way, the patch is attempted once; without server support, the value is PUT instead
+	tests := []struct {
+		name       string
+		mergePatch bool
+	}{
+		{"patched", true},
+		{"fallback to PUT", false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			fake, testServer := newFakeBosk(t)
+			defer testServer.Close()
+			if test.mergePatch {
+				fake.enableMergePatch()
+			}
+
+			config := func(name string) string {
+				return fmt.Sprintf(`
+					provider "bosk" {
+						basic_auth_var_suffix = "NO_AUTH"
+					}
+					resource "bosk_node" "test" {
+						url           = "%s/bosk/node"
+						value_json    = jsonencode({ id = "node", name = %q })
+						update_method = "merge_patch"
+					}
+				`, testServer.URL, name)
+			}
+			checkNode := func(expected string) resource.TestCheckFunc {
+				return func(*terraform.State) error {
+					if actual, _ := fake.get("/bosk/node"); !jsonEquivalent(actual, expected) {
+						return fmt.Errorf("expected node to be %s; found %s", expected, actual)
+					}
+					return nil
+				}
+			}
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					// Creation always uses PUT
+					{
+						Config: config("before"),
+						Check:  checkNode(`{"id":"node","name":"before"}`),
+					},
+					{
+						Config: config("after"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							checkNode(`{"id":"node","name":"after"}`),
+							func(*terraform.State) error {
+								patches := fake.patchesReceived()
+								if len(patches) != 1 || patches[0] != `{"name":"after"}` {
+									return fmt.Errorf("expected a single merge patch of the name; got %v", patches)
+								}
+								return nil
+							},
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccNodeResourceUpdateMethodValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "bosk" {
+						basic_auth_var_suffix = "NO_AUTH"
+					}
+					resource "bosk_node" "test" {
+						url           = "http://localhost:1740/bosk/node"
+						value_json    = jsonencode({ id = "node" })
+						update_method = "post"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid update_method`),
+			},
+		},
+	})
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// nodeClaims records the nodes that the resources sharing a provider instance plan to manage,
+// so that resources fighting over the same node can be reported during planning.
+type nodeClaims struct {
+	mutex  sync.Mutex
+	claims []nodeClaim
+}
+
+type nodeClaim struct {
+	owner    string
+	url      string
+	excluded []string
+}
+
+// covers reports whether the claim extends to the node at url.
+func (c nodeClaim) covers(url string) bool {
+	if !isSameOrDescendant(url, c.url) {
+		return false
+	}
+	for _, excluded := range c.excluded {
+		if isSameOrDescendant(url, excluded) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSameOrDescendant reports whether url addresses the same node as ancestor, or a node below it.
+func isSameOrDescendant(url string, ancestor string) bool {
+	url = strings.TrimSuffix(url, "/")
+	ancestor = strings.TrimSuffix(ancestor, "/")
+	return url == ancestor || strings.HasPrefix(url, ancestor+"/")
+}
+
+// relativeURL returns the path from ancestor to url, which must be its descendant.
+func relativeURL(url string, ancestor string) string {
+	return strings.TrimPrefix(url, strings.TrimSuffix(ancestor, "/")+"/")
+}
+
+// claim records the owner's claim to the node at url, apart from the excluded descendants,
+// and returns a description of each overlap with another owner's claim.
+// A resource can be planned more than once, as when Terraform plans its replacement,
+// so a new claim by the same owner replaces its previous one.
+func (c *nodeClaims) claim(owner string, url string, excluded []string) []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	newClaim := nodeClaim{owner: owner, url: url, excluded: excluded}
+	var overlaps []string
+	others := c.claims[:0]
+	for _, other := range c.claims {
+		if other.owner == owner {
+			continue
+		}
+		others = append(others, other)
+		switch {
+		case strings.TrimSuffix(other.url, "/") == strings.TrimSuffix(url, "/"):
+			overlaps = append(overlaps, fmt.Sprintf("%v is also managed by another resource. Each apply of one would undo the other's changes.", url))
+		case other.covers(url):
+			overlaps = append(overlaps, fmt.Sprintf("%v is inside %v, which is managed by another resource. If that resource is a bosk_node, add %q to its excluded_child_paths.", url, other.url, relativeURL(url, other.url)))
+		case newClaim.covers(other.url):
+			overlaps = append(overlaps, fmt.Sprintf("%v, which is managed by another resource, is inside %v. If this resource is a bosk_node, add %q to its excluded_child_paths.", other.url, url, relativeURL(other.url, url)))
+		}
+	}
+	c.claims = append(others, newClaim)
+	return overlaps
+}
+
+// privateState is the resource's private state, as in resource.ModifyPlanResponse.Private.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// claimOwnerKey is the private state key holding the token that identifies a resource's claims.
+const claimOwnerKey = "claim_owner"
+
+// claimOwner returns the token identifying the resource whose private state this is,
+// choosing a random one if it has none yet. Terraform passes the private state from one plan of a resource
+// to the next, so the token stays the same when the resource is planned again.
+func claimOwner(ctx context.Context, private privateState, diag *diag.Diagnostics) string {
+	stored, diags := private.GetKey(ctx, claimOwnerKey)
+	diag.Append(diags...)
+	var owner string
+	if len(stored) > 0 && json.Unmarshal(stored, &owner) == nil && owner != "" {
+		return owner
+	}
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		diag.AddError("Unable to identify resource", fmt.Sprintf("Unable to generate a random token: %s", err))
+		return ""
+	}
+	owner = hex.EncodeToString(token)
+	encoded, _ := json.Marshal(owner)
+	diag.Append(private.SetKey(ctx, claimOwnerKey, encoded)...)
+	return owner
+}
+
+// ClaimNode records that a resource plans to manage the node at url, apart from the excluded descendants,
+// adding an error to diag for each other resource of the same provider that manages an overlapping node.
+// Since every apply of one would undo the other's changes, the plan would never converge.
+func (client *BoskClient) ClaimNode(ctx context.Context, private privateState, url string, excluded []string, urlAttribute path.Path, diag *diag.Diagnostics) {
+	owner := claimOwner(ctx, private, diag)
+	if diag.HasError() {
+		return
+	}
+	for _, overlap := range client.claims.claim(owner, url, excluded) {
+		diag.AddAttributeError(urlAttribute, "Overlapping bosk nodes", overlap)
+	}
+}
@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNodeClaims(t *testing.T) {
+	tests := []struct {
+		description string
+		existing    nodeClaim
+		claim       nodeClaim
+		overlap     string
+	}{
+		{"duplicate", nodeClaim{url: "http://h/bosk/a"}, nodeClaim{url: "http://h/bosk/a/"}, "also managed"},
+		{"descendant", nodeClaim{url: "http://h/bosk/a"}, nodeClaim{url: "http://h/bosk/a/b/c"}, `add "b/c" to its excluded_child_paths`},
+		{"ancestor", nodeClaim{url: "http://h/bosk/a/b"}, nodeClaim{url: "http://h/bosk/a"}, `add "b" to its excluded_child_paths`},
+		{"sibling", nodeClaim{url: "http://h/bosk/a"}, nodeClaim{url: "http://h/bosk/ab"}, ""},
+		{"excluded descendant", nodeClaim{url: "http://h/bosk/a", excluded: []string{"http://h/bosk/a/b"}}, nodeClaim{url: "http://h/bosk/a/b/c"}, ""},
+		{"excluded ancestor", nodeClaim{url: "http://h/bosk/a/b"}, nodeClaim{url: "http://h/bosk/a", excluded: []string{"http://h/bosk/a/b"}}, ""},
+		{"other exclusion", nodeClaim{url: "http://h/bosk/a", excluded: []string{"http://h/bosk/a/x"}}, nodeClaim{url: "http://h/bosk/a/b"}, "inside"},
+	}
+	for _, test := range tests {
+		var claims nodeClaims
+		if overlaps := claims.claim("existing", test.existing.url, test.existing.excluded); len(overlaps) != 0 {
+			t.Fatalf("%s: unexpected overlaps for the first claim: %v", test.description, overlaps)
+		}
+		overlaps := claims.claim("new", test.claim.url, test.claim.excluded)
+		if test.overlap == "" {
+			if len(overlaps) != 0 {
+				t.Errorf("%s: expected no overlaps; got %v", test.description, overlaps)
+			}
+		} else if len(overlaps) != 1 || !strings.Contains(overlaps[0], test.overlap) {
+			t.Errorf("%s: expected an overlap mentioning %q; got %v", test.description, test.overlap, overlaps)
+		}
+	}
+}
+
+func TestNodeClaimsByTheSameOwner(t *testing.T) {
+	var claims nodeClaims
+	claims.claim("a", "http://h/bosk/x", nil)
+
+	// As when Terraform plans the replacement of a resource
+	if overlaps := claims.claim("a", "http://h/bosk/x", nil); len(overlaps) != 0 {
+		t.Errorf("expected a repeated claim not to overlap itself; got %v", overlaps)
+	}
+
+	// A new claim by the same owner releases its previous one
+	claims.claim("a", "http://h/bosk/y", nil)
+	if overlaps := claims.claim("b", "http://h/bosk/x", nil); len(overlaps) != 0 {
+		t.Errorf("expected the released node to be available; got %v", overlaps)
+	}
+	if overlaps := claims.claim("b", "http://h/bosk/y", nil); len(overlaps) != 1 {
+		t.Errorf("expected another owner's claim to overlap; got %v", overlaps)
+	}
+}
+
+// fakePrivateState is a privateState backed by a map.
+type fakePrivateState map[string][]byte
+
+func (p fakePrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p fakePrivateState) SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func TestClaimOwner(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+	first, second := fakePrivateState{}, fakePrivateState{}
+	owner := claimOwner(ctx, first, &diags)
+	if diags.HasError() || owner == "" {
+		t.Fatalf("expected a token; got %q, %v", owner, diags)
+	}
+	if again := claimOwner(ctx, first, &diags); again != owner {
+		t.Errorf("expected the same private state to yield the same token; got %q then %q", owner, again)
+	}
+	if other := claimOwner(ctx, second, &diags); other == owner {
+		t.Errorf("expected different private states to yield different tokens; both got %q", owner)
+	}
+}
+
+func TestValueToPutPreservesExcludedChildren(t *testing.T) {
+	data := NodeModel{
+		Value_json: NewJSONValue(`{"name":"new","settings":{"debug":true},"worlds":[{"earth":{"id":"earth","v":1}}]}`),
+		Excluded:   stringList("settings", "worlds/earth", "worlds/mars", "missing/child"),
+	}
+	live := NodeContents{
+		Found:    true,
+		JSON:     `{"name":"old","settings":{"debug":false},"worlds":[{"mars":{"id":"mars"}},{"earth":{"id":"earth","v":2}}]}`,
+		Revision: "live",
+	}
+	var diags diag.Diagnostics
+	value, revision := data.valueToPut(live, "state", &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	expected := `{"name":"new","settings":{"debug":false},"worlds":[{"earth":{"id":"earth","v":2}},{"mars":{"id":"mars"}}]}`
+	if value != expected {
+		t.Errorf("expected %s; got %s", expected, value)
+	}
+	if revision != "live" {
+		t.Errorf("expected the write to be conditional on the live revision; got %q", revision)
+	}
+
+	value, revision = data.valueToPut(NodeContents{Found: false}, "", &diags)
+	if value != data.Value_json.ValueString() || revision != "" {
+		t.Errorf("expected a new node to be written as configured; got %s, %q", value, revision)
+	}
+}
+
+func TestSpliceExcludedIgnoresLiveChildren(t *testing.T) {
+	// As in Read: the live value, with excluded children as they were in the prior state
+	live := `{"name":"old","settings":{"debug":false},"extra":{"a":1}}`
+	prior := `{"name":"new","settings":{"debug":true}}`
+	excluded := [][]string{{"settings"}, {"extra"}}
+	value, err := spliceExcluded(live, prior, excluded, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := `{"name":"old","settings":{"debug":true}}`; value != expected {
+		t.Errorf("expected %s; got %s", expected, value)
+	}
+}
+
+func TestDeclaredFields(t *testing.T) {
+	live := `{"id":"a","name":"old","default":7}`
+	merged, err := mergeDeclaredFields(live, `{"name":"new","extra":[1]}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := `{"default":7,"extra":[1],"id":"a","name":"new"}`; merged != expected {
+		t.Errorf("expected %s; got %s", expected, merged)
+	}
+
+	owned, err := declaredFieldsOf(live, `{"name":"new","extra":[1]}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := `{"name":"old"}`; owned != expected {
+		t.Errorf("expected %s; got %s", expected, owned)
+	}
+
+	// A live value that isn't an object is replaced on write, and shows up whole as drift
+	if merged, _ := mergeDeclaredFields(`"scalar"`, `{"name":"new"}`); merged != `{"name":"new"}` {
+		t.Errorf("expected the declared object to replace a scalar; got %s", merged)
+	}
+	if owned, _ := declaredFieldsOf(`"scalar"`, `{"name":"new"}`); owned != `"scalar"` {
+		t.Errorf("expected a scalar to be reported as is; got %s", owned)
+	}
+}
+
+func TestValueToPutMergesDeclaredFields(t *testing.T) {
+	data := NodeModel{
+		Value_json: NewJSONValue(`{"name":"new","settings":{"debug":true}}`),
+		Ownership:  types.StringValue(ownershipDeclaredFields),
+		Excluded:   stringList("settings/debug"),
+	}
+	live := NodeContents{
+		Found:    true,
+		JSON:     `{"name":"old","owner":"someone else","settings":{"debug":false,"verbose":true}}`,
+		Revision: "live",
+	}
+	var diags diag.Diagnostics
+	value, revision := data.valueToPut(live, "state", &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	expected := `{"name":"new","owner":"someone else","settings":{"debug":false}}`
+	if value != expected {
+		t.Errorf("expected %s; got %s", expected, value)
+	}
+	if revision != "live" {
+		t.Errorf("expected the write to be conditional on the live revision; got %q", revision)
+	}
+
+	owned := data.ownedValue(live.JSON, &diags)
+	if expected := `{"name":"old","settings":{"debug":true,"verbose":true}}`; owned != expected {
+		t.Errorf("expected %s; got %s", expected, owned)
+	}
+}
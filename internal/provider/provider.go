@@ -8,16 +8,24 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure BoskProvider satisfies various provider interfaces.
 var _ provider.Provider = &BoskProvider{}
+var _ provider.ProviderWithFunctions = &BoskProvider{}
 
 // BoskProvider defines the provider implementation.
 type BoskProvider struct {
@@ -30,6 +38,14 @@ type BoskProvider struct {
 // BoskProviderModel describes the provider data model.
 type BoskProviderModel struct {
 	BasicAuthVarSuffix types.String `tfsdk:"basic_auth_var_suffix"`
+	BaseURL            types.String `tfsdk:"base_url"`
+	RevisionHeader     types.String `tfsdk:"revision_header"`
+	MaxRetries         types.Int64  `tfsdk:"max_retries"`
+	RetryMinDelay      types.String `tfsdk:"retry_min_delay"`
+	RetryMaxDelay      types.String `tfsdk:"retry_max_delay"`
+	RequestTimeout     types.String `tfsdk:"request_timeout"`
+	Auth               *AuthModel   `tfsdk:"auth"`
+	TLS                *TLSModel    `tfsdk:"tls"`
 }
 
 func (p *BoskProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -41,10 +57,39 @@ func (p *BoskProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"basic_auth_var_suffix": schema.StringAttribute{
-				MarkdownDescription: "Selects the environment variables to use for HTTP basic authentication; namely TF_BOSK_USERNAME_xxx and TF_BOSK_PASSWORD_xxx. If you don't want to use basic auth, specify NO_AUTH.",
-				Required:            true,
+				MarkdownDescription: "Selects the environment variables to use for HTTP basic authentication; namely TF_BOSK_USERNAME_xxx and TF_BOSK_PASSWORD_xxx. If you don't want to use basic auth, specify NO_AUTH. Exactly one of `basic_auth_var_suffix` and `auth` must be specified.",
+				Optional:            true,
+			},
+			"base_url": schema.StringAttribute{
+				MarkdownDescription: "The HTTP address against which the `path` attribute of bosk nodes is resolved. Defaults to the TF_BOSK_URL environment variable.",
+				Optional:            true,
+				Validators:          []validator.String{httpURLValidator{}},
+			},
+			"revision_header": schema.StringAttribute{
+				MarkdownDescription: "The HTTP response header identifying the revision of a bosk node. Its value is recorded in the `etag` attribute, and sent back in `If-Match` on writes so that concurrent changes are detected rather than overwritten. Defaults to `ETag`. Set to the empty string to disable.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "The number of times to retry a GET, PUT or DELETE request that fails with a connection error, a 5xx status, or 429 Too Many Requests. Defaults to 4. Set to 0 to disable retries.",
+				Optional:            true,
+			},
+			"retry_min_delay": schema.StringAttribute{
+				MarkdownDescription: "The delay before the first retry, as a Go duration such as `500ms`; it doubles for each subsequent retry, with random jitter. Defaults to `1s`.",
+				Optional:            true,
+			},
+			"retry_max_delay": schema.StringAttribute{
+				MarkdownDescription: "The longest delay between retries, including any delay requested by the server in a `Retry-After` header. Defaults to `30s`.",
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "The time limit for each individual HTTP request, including reading the response, as a Go duration such as `30s`. Each retry gets its own time limit; the overall time for an operation is governed by the resource's `timeouts`. Defaults to `1m`. Set to `0s` for no limit.",
+				Optional:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"auth": authBlock(),
+			"tls":  tlsBlock(),
+		},
 	}
 }
 
@@ -55,53 +100,187 @@ func (p *BoskProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if data.hasUnknownValues() {
+		// Settings derived from other resources aren't known until those are applied.
+		// Leave the provider unconfigured until then, rather than failing the plan.
+		tflog.Debug(ctx, "Deferring provider configuration until connection settings are known")
+		return
+	}
+
+	var baseURL = data.BaseURL.ValueString()
+	if data.BaseURL.IsNull() {
+		baseURL = os.Getenv("TF_BOSK_URL")
+	}
+	if baseURL != "" {
+		if problem := checkHTTPURL(baseURL); problem != "" {
+			resp.Diagnostics.AddAttributeError(path.Root("base_url"), "Invalid base_url", problem)
+		}
+	}
+
+	var settings = BoskClientSettings{
+		BaseURL:        baseURL,
+		RevisionHeader: "ETag",
+	}
+	if !data.RevisionHeader.IsNull() {
+		settings.RevisionHeader = data.RevisionHeader.ValueString()
+	}
+	settings.MaxRetries = 4
+	if !data.MaxRetries.IsNull() {
+		settings.MaxRetries = int(data.MaxRetries.ValueInt64())
+		if settings.MaxRetries < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid max_retries",
+				fmt.Sprintf("Expected max_retries to be zero or more. Got: %v", settings.MaxRetries),
+			)
+		}
+	}
+	settings.RetryMinDelay = parseDurationAttribute(data.RetryMinDelay, path.Root("retry_min_delay"), time.Second, &resp.Diagnostics)
+	settings.RetryMaxDelay = parseDurationAttribute(data.RetryMaxDelay, path.Root("retry_max_delay"), 30*time.Second, &resp.Diagnostics)
+
+	var httpClient = &http.Client{
+		Transport: newTransport(data.TLS, &resp.Diagnostics),
+		Timeout:   parseDurationAttribute(data.RequestTimeout, path.Root("request_timeout"), time.Minute, &resp.Diagnostics),
+	}
+
+	var auth Authenticator
+	if data.Auth != nil {
+		if !data.BasicAuthVarSuffix.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("basic_auth_var_suffix"),
+				"Conflicting authentication settings",
+				"Only one of basic_auth_var_suffix and the auth block may be specified",
+			)
+		}
+		if data.Auth.hasUnknownValues() {
+			// Credentials derived from other resources aren't known until those are applied.
+			// Leave the provider unconfigured until then, rather than failing the plan.
+			tflog.Debug(ctx, "Deferring provider configuration until auth settings are known")
+			return
+		}
+		auth = data.Auth.authenticator(ctx, httpClient, &resp.Diagnostics)
+	} else if data.BasicAuthVarSuffix.IsNull() {
+		resp.Diagnostics.AddError(
+			"Missing authentication settings",
+			"Either basic_auth_var_suffix or the auth block must be specified. If you don't want to use authentication, specify basic_auth_var_suffix = \"NO_AUTH\".",
+		)
+	} else {
+		auth = basicAuthFromEnvironment(data.BasicAuthVarSuffix.ValueString(), &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	var suffix = data.BasicAuthVarSuffix.ValueString()
+	var client *BoskClient
+	if auth == nil {
+		client = NewBoskClientWithoutAuth(httpClient, settings)
+	} else {
+		client = NewBoskClient(httpClient, settings, auth)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.DataSourceData = client
+	resp.ResourceData = client
+}
+
+// hasUnknownValues reports whether any of the provider's connection settings is unknown.
+// The auth block is checked separately, once the authentication settings have been validated.
+func (m *BoskProviderModel) hasUnknownValues() bool {
+	values := []attr.Value{m.BasicAuthVarSuffix, m.BaseURL, m.RevisionHeader, m.MaxRetries, m.RetryMinDelay, m.RetryMaxDelay, m.RequestTimeout}
+	for _, value := range values {
+		if value.IsUnknown() {
+			return true
+		}
+	}
+	return m.TLS != nil && m.TLS.hasUnknownValues()
+}
+
+// addUnconfiguredError reports that a request can't be made because the provider deferred its configuration.
+func addUnconfiguredError(diags *diag.Diagnostics) {
+	diags.AddError(
+		"Provider not yet configured",
+		"The provider's settings depend on values that aren't known yet, such as attributes of resources that haven't been created. "+
+			"Requests to bosk can't be made until they are.",
+	)
+}
+
+// basicAuthFromEnvironment implements basic_auth_var_suffix.
+// Returns nil for NO_AUTH.
+func basicAuthFromEnvironment(suffix string, diags *diag.Diagnostics) Authenticator {
 	var usernameVar = "TF_BOSK_USERNAME_" + suffix
 	var passwordVar = "TF_BOSK_PASSWORD_" + suffix
 	username, usernameExists := os.LookupEnv(usernameVar)
 	password, passwordExists := os.LookupEnv(passwordVar)
-	var client *BoskClient
 
 	if suffix == "NO_AUTH" {
-		client = NewBoskClientWithoutAuth(http.DefaultClient)
 		if usernameExists {
-			resp.Diagnostics.AddWarning(
+			diags.AddWarning(
 				"NO_AUTH suffix overrides username environment variable",
 				fmt.Sprintf("Based on basic_auth_var_suffix of \"%v\", ignoring environment variable \"TF_BOSK_USERNAME_%v\"", suffix, suffix),
 			)
 		}
 		if passwordExists {
-			resp.Diagnostics.AddWarning(
+			diags.AddWarning(
 				"NO_AUTH suffix overrides password environment variable",
 				fmt.Sprintf("Based on basic_auth_var_suffix of \"%v\", ignoring environment variable \"TF_BOSK_PASSWORD_%v\"", suffix, suffix),
 			)
 		}
+		return nil
 	} else if usernameExists && passwordExists {
-		client = NewBoskClient(http.DefaultClient, username, password)
+		return NewBasicAuth(username, password)
 	} else {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Missing environment variables for authentication",
 			fmt.Sprintf("Based on basic_auth_var_suffix of \"%v\", expected to find environment variables \"TF_BOSK_USERNAME_%v\" and \"TF_BOSK_PASSWORD_%v\"", suffix, suffix, suffix),
 		)
+		return nil
 	}
-	if resp.Diagnostics.HasError() {
-		return
-	}
+}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+// parseDurationAttribute interprets an optional attribute as a Go duration, returning defaultValue if it is null.
+func parseDurationAttribute(value types.String, attributePath path.Path, defaultValue time.Duration, diags *diag.Diagnostics) time.Duration {
+	if value.IsNull() {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(attributePath, "Invalid duration", fmt.Sprintf("Expected a duration such as \"1s\" or \"500ms\": %s", err))
+		return defaultValue
+	}
+	if duration < 0 {
+		diags.AddAttributeError(attributePath, "Invalid duration", fmt.Sprintf("Expected a non-negative duration. Got: %v", value.ValueString()))
+		return defaultValue
+	}
+	return duration
 }
 
 func (p *BoskProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewNodeResource,
+		NewCatalogEntryResource,
+		NewListingMemberResource,
+		NewSideTableEntryResource,
 	}
 }
 
 func (p *BoskProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewNodeDataSource,
+		NewCatalogDataSource,
+		NewNodeConditionDataSource,
+	}
+}
+
+func (p *BoskProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewCatalogEncodeFunction,
+		NewCatalogDecodeFunction,
+		NewListingEncodeFunction,
+		NewSideTableEncodeFunction,
+		NewReferenceFunction,
 	}
 }
 
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// AuthModel describes the provider's auth block. Exactly one of its nested blocks may be present.
+type AuthModel struct {
+	Basic             *BasicAuthModel             `tfsdk:"basic"`
+	Bearer            *BearerAuthModel            `tfsdk:"bearer"`
+	ClientCredentials *ClientCredentialsAuthModel `tfsdk:"oauth2_client_credentials"`
+}
+
+type BasicAuthModel struct {
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+}
+
+type BearerAuthModel struct {
+	Token types.String `tfsdk:"token"`
+}
+
+type ClientCredentialsAuthModel struct {
+	TokenURL     types.String `tfsdk:"token_url"`
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	Scopes       types.List   `tfsdk:"scopes"`
+}
+
+func authBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Configures how requests are authenticated, as an alternative to `basic_auth_var_suffix`. Specify exactly one of the nested blocks.",
+		Blocks: map[string]schema.Block{
+			"basic": schema.SingleNestedBlock{
+				MarkdownDescription: "HTTP basic authentication.",
+				Attributes: map[string]schema.Attribute{
+					"username": schema.StringAttribute{
+						MarkdownDescription: "The username.",
+						Optional:            true,
+					},
+					"password": schema.StringAttribute{
+						MarkdownDescription: "The password.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+				},
+			},
+			"bearer": schema.SingleNestedBlock{
+				MarkdownDescription: "A static bearer token, sent in the `Authorization` header.",
+				Attributes: map[string]schema.Attribute{
+					"token": schema.StringAttribute{
+						MarkdownDescription: "The token. Defaults to the TF_BOSK_TOKEN environment variable.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+				},
+			},
+			"oauth2_client_credentials": schema.SingleNestedBlock{
+				MarkdownDescription: "Bearer tokens obtained using the OAuth2 client credentials grant. Tokens are cached until they expire or are rejected by the server.",
+				Attributes: map[string]schema.Attribute{
+					"token_url": schema.StringAttribute{
+						MarkdownDescription: "The address of the authorization server's token endpoint.",
+						Optional:            true,
+					},
+					"client_id": schema.StringAttribute{
+						MarkdownDescription: "The OAuth2 client identifier.",
+						Optional:            true,
+					},
+					"client_secret": schema.StringAttribute{
+						MarkdownDescription: "The OAuth2 client secret. Defaults to the TF_BOSK_CLIENT_SECRET environment variable.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"scopes": schema.ListAttribute{
+						MarkdownDescription: "The scopes to request.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// authenticator builds the Authenticator selected by the auth block.
+func (m *AuthModel) authenticator(ctx context.Context, httpClient *http.Client, diags *diag.Diagnostics) Authenticator {
+	authPath := path.Root("auth")
+	count := 0
+	for _, present := range []bool{m.Basic != nil, m.Bearer != nil, m.ClientCredentials != nil} {
+		if present {
+			count++
+		}
+	}
+	if count != 1 {
+		diags.AddAttributeError(authPath, "Invalid auth block", fmt.Sprintf("Expected exactly one of basic, bearer or oauth2_client_credentials. Got: %v", count))
+		return nil
+	}
+
+	switch {
+	case m.Basic != nil:
+		basicPath := authPath.AtName("basic")
+		username := requiredString(m.Basic.Username, "", basicPath.AtName("username"), diags)
+		password := requiredString(m.Basic.Password, "", basicPath.AtName("password"), diags)
+		return NewBasicAuth(username, password)
+	case m.Bearer != nil:
+		token := requiredString(m.Bearer.Token, "TF_BOSK_TOKEN", authPath.AtName("bearer").AtName("token"), diags)
+		return NewBearerAuth(token)
+	default:
+		clientCredentialsPath := authPath.AtName("oauth2_client_credentials")
+		tokenURL := requiredString(m.ClientCredentials.TokenURL, "", clientCredentialsPath.AtName("token_url"), diags)
+		clientID := requiredString(m.ClientCredentials.ClientID, "", clientCredentialsPath.AtName("client_id"), diags)
+		clientSecret := requiredString(m.ClientCredentials.ClientSecret, "TF_BOSK_CLIENT_SECRET", clientCredentialsPath.AtName("client_secret"), diags)
+		var scopes []string
+		if !m.ClientCredentials.Scopes.IsNull() {
+			diags.Append(m.ClientCredentials.Scopes.ElementsAs(ctx, &scopes, false)...)
+		}
+		return NewClientCredentialsAuth(httpClient, tokenURL, clientID, clientSecret, scopes)
+	}
+}
+
+// hasUnknownValues reports whether any attribute of the selected nested block is unknown,
+// as when it's derived from a resource that hasn't been applied yet.
+func (m *AuthModel) hasUnknownValues() bool {
+	var values []attr.Value
+	if m.Basic != nil {
+		values = append(values, m.Basic.Username, m.Basic.Password)
+	}
+	if m.Bearer != nil {
+		values = append(values, m.Bearer.Token)
+	}
+	if m.ClientCredentials != nil {
+		values = append(values, m.ClientCredentials.TokenURL, m.ClientCredentials.ClientID, m.ClientCredentials.ClientSecret, m.ClientCredentials.Scopes)
+		values = append(values, m.ClientCredentials.Scopes.Elements()...)
+	}
+	for _, value := range values {
+		if value.IsUnknown() {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredString returns the value of an attribute that must be set,
+// either in the configuration or, if envVar is not empty, in that environment variable.
+// Unknown values aren't missing; callers are expected to check for them first, with hasUnknownValues.
+func requiredString(value types.String, envVar string, attributePath path.Path, diags *diag.Diagnostics) string {
+	if value.IsUnknown() {
+		return ""
+	}
+	if !value.IsNull() {
+		return value.ValueString()
+	}
+	if envVar != "" {
+		if result, ok := os.LookupEnv(envVar); ok {
+			return result
+		}
+		diags.AddAttributeError(attributePath, "Missing required value", fmt.Sprintf("Expected a value in the configuration or in environment variable \"%v\"", envVar))
+		return ""
+	}
+	diags.AddAttributeError(attributePath, "Missing required value", "Expected a value in the configuration")
+	return ""
+}
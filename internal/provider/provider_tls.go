@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TLSModel describes the provider's tls block.
+type TLSModel struct {
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientCertPEM      types.String `tfsdk:"client_cert_pem"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+	ClientKeyPEM       types.String `tfsdk:"client_key_pem"`
+	ServerName         types.String `tfsdk:"server_name"`
+	MinVersion         types.String `tfsdk:"min_version"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+}
+
+func tlsBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Configures TLS for `https` connections to bosk servers and OAuth2 token endpoints.",
+		Attributes: map[string]schema.Attribute{
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file of PEM-encoded CA certificates to trust instead of the system's. Conflicts with `ca_cert_pem`.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded CA certificates to trust instead of the system's. Conflicts with `ca_cert_file`.",
+				Optional:            true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing a PEM-encoded client certificate to present for mutual TLS. Conflicts with `client_cert_pem`.",
+				Optional:            true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "A PEM-encoded client certificate to present for mutual TLS. Conflicts with `client_cert_file`.",
+				Optional:            true,
+			},
+			"client_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing the PEM-encoded private key for the client certificate. Conflicts with `client_key_pem`.",
+				Optional:            true,
+			},
+			"client_key_pem": schema.StringAttribute{
+				MarkdownDescription: "The PEM-encoded private key for the client certificate. Conflicts with `client_key_file`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"server_name": schema.StringAttribute{
+				MarkdownDescription: "Overrides the host name used to verify the server's certificate and sent in SNI.",
+				Optional:            true,
+			},
+			"min_version": schema.StringAttribute{
+				MarkdownDescription: "The minimum TLS version to accept: one of `1.0`, `1.1`, `1.2` or `1.3`. Defaults to `1.2`.",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Accept any server certificate, without verifying its chain or host name. Only suitable for local development.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// hasUnknownValues reports whether any attribute of the tls block is unknown,
+// as when it's derived from a resource that hasn't been applied yet.
+func (m *TLSModel) hasUnknownValues() bool {
+	values := []attr.Value{m.CACertFile, m.CACertPEM, m.ClientCertFile, m.ClientCertPEM, m.ClientKeyFile, m.ClientKeyPEM, m.ServerName, m.MinVersion, m.InsecureSkipVerify}
+	for _, value := range values {
+		if value.IsUnknown() {
+			return true
+		}
+	}
+	return false
+}
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// newTransport builds the transport used for all of the provider's HTTP requests.
+// A nil model gives the default TLS settings.
+func newTransport(m *TLSModel, diags *diag.Diagnostics) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if m == nil {
+		return transport
+	}
+	config := transport.TLSClientConfig
+	tlsPath := path.Root("tls")
+
+	if caPEM, ok := fileOrPEM(m.CACertFile, m.CACertPEM, tlsPath.AtName("ca_cert_file"), tlsPath.AtName("ca_cert_pem"), diags); ok {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			diags.AddAttributeError(tlsPath, "Invalid CA certificates", "No PEM-encoded certificates could be parsed from the CA certificate bundle")
+		}
+		config.RootCAs = pool
+	}
+
+	certPEM, hasCert := fileOrPEM(m.ClientCertFile, m.ClientCertPEM, tlsPath.AtName("client_cert_file"), tlsPath.AtName("client_cert_pem"), diags)
+	keyPEM, hasKey := fileOrPEM(m.ClientKeyFile, m.ClientKeyPEM, tlsPath.AtName("client_key_file"), tlsPath.AtName("client_key_pem"), diags)
+	if hasCert != hasKey {
+		diags.AddAttributeError(tlsPath, "Incomplete client certificate", "A client certificate and its private key must be specified together")
+	} else if hasCert {
+		certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			diags.AddAttributeError(tlsPath, "Invalid client certificate", fmt.Sprintf("Unable to load client certificate and key: %s", err))
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	config.ServerName = m.ServerName.ValueString()
+
+	if !m.MinVersion.IsNull() {
+		version, ok := tlsVersions[m.MinVersion.ValueString()]
+		if !ok {
+			diags.AddAttributeError(tlsPath.AtName("min_version"), "Invalid TLS version", fmt.Sprintf("Expected one of \"1.0\", \"1.1\", \"1.2\" or \"1.3\". Got: %v", m.MinVersion.ValueString()))
+		}
+		config.MinVersion = version
+	}
+
+	if m.InsecureSkipVerify.ValueBool() {
+		config.InsecureSkipVerify = true
+		diags.AddAttributeWarning(tlsPath.AtName("insecure_skip_verify"), "TLS verification disabled", "Server certificates will not be verified. This is only suitable for local development.")
+	}
+
+	return transport
+}
+
+// fileOrPEM returns PEM data given either inline or as a file path, but not both.
+// Returns false if neither was given.
+func fileOrPEM(file types.String, pem types.String, filePath path.Path, pemPath path.Path, diags *diag.Diagnostics) ([]byte, bool) {
+	if !file.IsNull() && !pem.IsNull() {
+		diags.AddAttributeError(pemPath, "Conflicting TLS settings", fmt.Sprintf("Only one of %v and %v may be specified", filePath, pemPath))
+		return nil, false
+	}
+	if !pem.IsNull() {
+		return []byte(pem.ValueString()), true
+	}
+	if !file.IsNull() {
+		contents, err := os.ReadFile(file.ValueString())
+		if err != nil {
+			diags.AddAttributeError(filePath, "Unable to read file", err.Error())
+			return nil, false
+		}
+		return contents, true
+	}
+	return nil, false
+}
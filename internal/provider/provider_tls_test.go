@@ -0,0 +1,267 @@
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// newTestCertificate creates a self-signed certificate, returning it and its key in PEM form.
+func newTestCertificate(t *testing.T, commonName string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		DNSNames:              []string{commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM)
+}
+
+func serverCAPEM(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+func getWithTransport(t *testing.T, model *TLSModel, url string) error {
+	t.Helper()
+	var diags diag.Diagnostics
+	transport := newTransport(model, &diags)
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+	req, err := http.NewRequestWithContext(context.Background(), "GET", url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpResp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return err
+	}
+	httpResp.Body.Close()
+	return nil
+}
+
+func TestTransportDefault(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	if err := getWithTransport(t, nil, server.URL); err == nil {
+		t.Errorf("Expected the test server's certificate to be rejected without a custom CA")
+	}
+}
+
+func TestTransportCustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	model := &TLSModel{CACertPEM: types.StringValue(serverCAPEM(server))}
+	if err := getWithTransport(t, model, server.URL); err != nil {
+		t.Errorf("Expected the request to succeed with the server's CA: %v", err)
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte(serverCAPEM(server)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	model = &TLSModel{CACertFile: types.StringValue(caFile)}
+	if err := getWithTransport(t, model, server.URL); err != nil {
+		t.Errorf("Expected the request to succeed with the server's CA file: %v", err)
+	}
+}
+
+func TestTransportInsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	var diags diag.Diagnostics
+	newTransport(&TLSModel{InsecureSkipVerify: types.BoolValue(true)}, &diags)
+	if diags.WarningsCount() != 1 {
+		t.Errorf("Expected a warning about disabled verification. Got: %v", diags)
+	}
+	if err := getWithTransport(t, &TLSModel{InsecureSkipVerify: types.BoolValue(true)}, server.URL); err != nil {
+		t.Errorf("Expected the request to succeed without verification: %v", err)
+	}
+}
+
+func TestTransportServerName(t *testing.T) {
+	certPEM, keyPEM := newTestCertificate(t, "bosk.example")
+	certificate, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{certificate}}
+	server.StartTLS()
+	defer server.Close()
+
+	model := &TLSModel{CACertPEM: types.StringValue(certPEM)}
+	if err := getWithTransport(t, model, server.URL); err == nil {
+		t.Errorf("Expected host name verification to fail without server_name")
+	}
+	model.ServerName = types.StringValue("bosk.example")
+	if err := getWithTransport(t, model, server.URL); err != nil {
+		t.Errorf("Expected the request to succeed with server_name: %v", err)
+	}
+}
+
+func TestTransportClientCertificate(t *testing.T) {
+	clientCertPEM, clientKeyPEM := newTestCertificate(t, "terraform")
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM([]byte(clientCertPEM))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	model := &TLSModel{CACertPEM: types.StringValue(serverCAPEM(server))}
+	if err := getWithTransport(t, model, server.URL); err == nil {
+		t.Errorf("Expected the server to reject a request without a client certificate")
+	}
+
+	model.ClientCertPEM = types.StringValue(clientCertPEM)
+	model.ClientKeyPEM = types.StringValue(clientKeyPEM)
+	if err := getWithTransport(t, model, server.URL); err != nil {
+		t.Errorf("Expected the request to succeed with a client certificate: %v", err)
+	}
+}
+
+func TestTransportInvalidSettings(t *testing.T) {
+	certPEM, keyPEM := newTestCertificate(t, "terraform")
+	cases := map[string]TLSModel{
+		"conflicting CA":        {CACertPEM: types.StringValue(certPEM), CACertFile: types.StringValue("ca.pem")},
+		"invalid CA":            {CACertPEM: types.StringValue("not a certificate")},
+		"missing CA file":       {CACertFile: types.StringValue(filepath.Join(t.TempDir(), "missing.pem"))},
+		"certificate only":      {ClientCertPEM: types.StringValue(certPEM)},
+		"key only":              {ClientKeyPEM: types.StringValue(keyPEM)},
+		"mismatched key":        {ClientCertPEM: types.StringValue(certPEM), ClientKeyPEM: types.StringValue(certPEM)},
+		"unknown TLS version":   {MinVersion: types.StringValue("1.4")},
+		"malformed TLS version": {MinVersion: types.StringValue("TLS1.2")},
+	}
+	for name, model := range cases {
+		model := model
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			newTransport(&model, &diags)
+			if !diags.HasError() {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestTransportMinVersion(t *testing.T) {
+	var diags diag.Diagnostics
+	transport := newTransport(nil, &diags)
+	if transport.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("Expected TLS 1.2 by default. Got: %x", transport.TLSClientConfig.MinVersion)
+	}
+	transport = newTransport(&TLSModel{MinVersion: types.StringValue("1.3")}, &diags)
+	if diags.HasError() || transport.TLSClientConfig.MinVersion != tls.VersionTLS13 {
+		t.Errorf("Expected TLS 1.3. Got: %x %v", transport.TLSClientConfig.MinVersion, diags)
+	}
+}
+
+func TestUnknownSettingsAreDeferred(t *testing.T) {
+	known := BoskProviderModel{
+		BasicAuthVarSuffix: types.StringValue("NO_AUTH"),
+		BaseURL:            types.StringValue("http://localhost:1740/bosk"),
+		TLS:                &TLSModel{ServerName: types.StringValue("bosk.example.com")},
+	}
+	if known.hasUnknownValues() {
+		t.Errorf("expected known settings not to be deferred")
+	}
+	tests := []BoskProviderModel{
+		{BaseURL: types.StringUnknown()},
+		{RevisionHeader: types.StringUnknown()},
+		{MaxRetries: types.Int64Unknown()},
+		{RetryMaxDelay: types.StringUnknown()},
+		{RequestTimeout: types.StringUnknown()},
+		{TLS: &TLSModel{CACertPEM: types.StringUnknown()}},
+		{TLS: &TLSModel{InsecureSkipVerify: types.BoolUnknown()}},
+	}
+	for i, test := range tests {
+		if !test.hasUnknownValues() {
+			t.Errorf("case %v: expected unknown settings to be deferred", i)
+		}
+	}
+}
+
+func TestAccProviderUnknownTLSSettings(t *testing.T) {
+	fake := newFakeBoskHandler(t)
+	server := httptest.NewTLSServer(fake)
+	defer server.Close()
+
+	// The CA isn't known until terraform_data is created, so the provider is configured only during apply
+	config := fmt.Sprintf(`
+		resource "terraform_data" "ca" {
+			input = %q
+		}
+		provider "bosk" {
+			basic_auth_var_suffix = "NO_AUTH"
+			tls {
+				ca_cert_pem = terraform_data.ca.output
+			}
+		}
+		resource "bosk_node" "test" {
+			url        = "%s/bosk/settings"
+			value_json = jsonencode({ debug = true })
+		}
+	`, serverCAPEM(server), server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: func(*terraform.State) error {
+					if value, ok := fake.get("/bosk/settings"); !ok || value != `{"debug":true}` {
+						return fmt.Errorf("expected the node to be written over TLS; got %s", value)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
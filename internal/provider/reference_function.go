@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &ReferenceFunction{}
+
+func NewReferenceFunction() function.Function {
+	return &ReferenceFunction{}
+}
+
+// ReferenceFunction builds a bosk path from its segments.
+type ReferenceFunction struct{}
+
+func (f *ReferenceFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "reference"
+}
+
+func (f *ReferenceFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Builds a bosk Reference",
+		MarkdownDescription: "Returns the bosk path with the given segments, as used in the JSON encoding of a Reference and in a listing or side table `domain`. Each segment is URL-encoded as bosk expects, so `reference(\"worlds\", \"a/b\")` returns `/worlds/a%2Fb`. With no segments, returns the root path `/`.",
+		VariadicParameter: function.StringParameter{
+			Name:                "segments",
+			MarkdownDescription: "The unescaped segments of the path. Must not be empty.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ReferenceFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var segments []string
+
+	resp.Error = req.Arguments.Get(ctx, &segments)
+	if resp.Error != nil {
+		return
+	}
+
+	result, err := referencePath(segments)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
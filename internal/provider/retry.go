@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// isIdempotent reports whether a request with the given method can safely be sent more than once.
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "PUT", "DELETE":
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetryable reports whether the outcome of an attempt indicates a transient failure
+// that might succeed if tried again.
+func isRetryable(httpResp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return httpResp.StatusCode == http.StatusTooManyRequests || httpResp.StatusCode/100 == 5
+}
+
+// retryDelay determines how long to wait before the given retry (numbered from zero).
+// A Retry-After header from the server takes precedence over exponential backoff;
+// either way, the delay is capped at the configured maximum.
+func (settings BoskClientSettings) retryDelay(retry int, httpResp *http.Response) time.Duration {
+	if httpResp != nil {
+		if delay, ok := parseRetryAfter(httpResp.Header.Get("Retry-After"), time.Now()); ok {
+			return minDuration(delay, settings.RetryMaxDelay)
+		}
+	}
+
+	// Exponential backoff with "equal jitter": half the delay is fixed and half is random,
+	// so that concurrent clients spread out without any of them retrying immediately.
+	backoff := settings.RetryMinDelay
+	for i := 0; i < retry && backoff < settings.RetryMaxDelay; i++ {
+		backoff *= 2
+	}
+	backoff = minDuration(backoff, settings.RetryMaxDelay)
+	half := backoff / 2
+	if half <= 0 {
+		return backoff
+	}
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter interprets a Retry-After header, which may be either a number of seconds or an HTTP date.
+func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		delay := date.Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for the given duration, returning false early if the context is cancelled.
+func sleepContext(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func minDuration(a time.Duration, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// flakyServer fails the first few requests with the given status before delegating to next.
+func flakyServer(failures int32, status int, next http.Handler) (*httptest.Server, *int32) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			return
+		}
+		next.ServeHTTP(w, r)
+	}))
+	return server, &attempts
+}
+
+func TestRetriesTransientFailures(t *testing.T) {
+	for _, status := range []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusTooManyRequests} {
+		fake := newFakeBoskHandler(t)
+		fake.set("/bosk/node", `{"id":"node"}`)
+		server, attempts := flakyServer(2, status, fake)
+
+		client := NewBoskClientWithoutAuth(http.DefaultClient, BoskClientSettings{MaxRetries: 2, RetryMaxDelay: time.Millisecond})
+		var diags diag.Diagnostics
+		json, _ := client.GetJSONAsString(context.Background(), server.URL+"/bosk/node", &diags)
+		if diags.HasError() {
+			t.Errorf("status %d: unexpected error: %v", status, diags)
+		}
+		if json != `{"id":"node"}` {
+			t.Errorf("status %d: unexpected result %s", status, json)
+		}
+		if *attempts != 3 {
+			t.Errorf("status %d: expected 3 attempts, got %d", status, *attempts)
+		}
+		server.Close()
+	}
+}
+
+func TestRetriesAreLimited(t *testing.T) {
+	fake := newFakeBoskHandler(t)
+	server, attempts := flakyServer(100, http.StatusServiceUnavailable, fake)
+	defer server.Close()
+
+	client := NewBoskClientWithoutAuth(http.DefaultClient, BoskClientSettings{MaxRetries: 3, RetryMaxDelay: time.Millisecond})
+	var diags diag.Diagnostics
+	client.PutJSONAsString(context.Background(), server.URL+"/bosk/node", `{}`, "", &diags)
+	if !diags.HasError() {
+		t.Errorf("expected an error once retries are exhausted")
+	}
+	if *attempts != 4 {
+		t.Errorf("expected 4 attempts, got %d", *attempts)
+	}
+}
+
+func TestClientErrorsAreNotRetried(t *testing.T) {
+	fake := newFakeBoskHandler(t)
+	server, attempts := flakyServer(100, http.StatusBadRequest, fake)
+	defer server.Close()
+
+	client := NewBoskClientWithoutAuth(http.DefaultClient, BoskClientSettings{MaxRetries: 3, RetryMaxDelay: time.Millisecond})
+	var diags diag.Diagnostics
+	client.PutJSONAsString(context.Background(), server.URL+"/bosk/node", `{}`, "", &diags)
+	if !diags.HasError() {
+		t.Errorf("expected an error")
+	}
+	if *attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", *attempts)
+	}
+}
+
+func TestRetryWaitIsCancellable(t *testing.T) {
+	fake := newFakeBoskHandler(t)
+	server, _ := flakyServer(100, http.StatusServiceUnavailable, fake)
+	defer server.Close()
+
+	client := NewBoskClientWithoutAuth(http.DefaultClient, BoskClientSettings{MaxRetries: 3, RetryMinDelay: time.Hour, RetryMaxDelay: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var diags diag.Diagnostics
+	start := time.Now()
+	client.GetNode(ctx, server.URL+"/bosk/node", &diags)
+	if !diags.HasError() {
+		t.Errorf("expected an error")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("cancellation took too long: %v", elapsed)
+	}
+}
+
+func TestIsIdempotent(t *testing.T) {
+	for method, expected := range map[string]bool{"GET": true, "PUT": true, "DELETE": true, "POST": false, "PATCH": false} {
+		if isIdempotent(method) != expected {
+			t.Errorf("isIdempotent(%q) should be %v", method, expected)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header   string
+		expected time.Duration
+		ok       bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"0", 0, true},
+		{"-1", 0, false},
+		{"Sun, 01 Oct 2023 12:00:30 GMT", 30 * time.Second, true},
+		{"Sun, 01 Oct 2023 11:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+	for _, test := range tests {
+		delay, ok := parseRetryAfter(test.header, now)
+		if delay != test.expected || ok != test.ok {
+			t.Errorf("parseRetryAfter(%q): expected %v, %v; got %v, %v", test.header, test.expected, test.ok, delay, ok)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	settings := BoskClientSettings{RetryMinDelay: time.Second, RetryMaxDelay: 10 * time.Second}
+	for retry, maxExpected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		for i := 0; i < 20; i++ {
+			delay := settings.retryDelay(retry, nil)
+			if delay < maxExpected/2 || delay > maxExpected {
+				t.Errorf("retry %d: delay %v outside [%v, %v]", retry, delay, maxExpected/2, maxExpected)
+			}
+		}
+	}
+
+	httpResp := &http.Response{Header: http.Header{"Retry-After": []string{"5"}}}
+	if delay := settings.retryDelay(0, httpResp); delay != 5*time.Second {
+		t.Errorf("expected Retry-After to be honored; got %v", delay)
+	}
+	httpResp.Header.Set("Retry-After", "3600")
+	if delay := settings.retryDelay(0, httpResp); delay != 10*time.Second {
+		t.Errorf("expected Retry-After to be capped; got %v", delay)
+	}
+}
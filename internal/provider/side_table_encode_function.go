@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &SideTableEncodeFunction{}
+
+func NewSideTableEncodeFunction() function.Function {
+	return &SideTableEncodeFunction{}
+}
+
+// SideTableEncodeFunction builds bosk's SideTable encoding from a map of values.
+type SideTableEncodeFunction struct{}
+
+func (f *SideTableEncodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "side_table_encode"
+}
+
+func (f *SideTableEncodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Encodes a bosk SideTable",
+		MarkdownDescription: "Returns the JSON encoding bosk uses for a SideTable, like `{\"domain\":\"/worlds\",\"valuesById\":[{\"earth\":\"blue\"}]}`. Entries appear in order of id, since Terraform maps have no other order.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "The bosk path of the catalog containing the entities the side table describes, such as `/worlds`.",
+			},
+			function.MapParameter{
+				Name:                "values_json",
+				MarkdownDescription: "The JSON-encoded value for each entity, keyed by id.",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SideTableEncodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domain string
+	var valuesJSON map[string]string
+
+	resp.Error = req.Arguments.Get(ctx, &domain, &valuesJSON)
+	if resp.Error != nil {
+		return
+	}
+
+	if problem := checkReference(domain); problem != "" {
+		resp.Error = function.NewArgumentFuncError(0, problem)
+		return
+	}
+	entries, err := catalogEntriesFromJSON(valuesJSON)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+	result, err := encodeSideTable(domain, entries)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
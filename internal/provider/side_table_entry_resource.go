@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NewSideTableEntryResource manages the value associated with a single entity in a bosk SideTable,
+// leaving the side table's other entries to be managed independently.
+func NewSideTableEntryResource() resource.Resource {
+	return &EntryResource{kind: sideTableEntryKind}
+}
+
+// SideTableEntryModel is the EntryModel of a side table entry.
+type SideTableEntryModel struct {
+	ContainerURL  types.String   `tfsdk:"side_table_url"`
+	ContainerPath types.String   `tfsdk:"side_table_path"`
+	ID            types.String   `tfsdk:"id"`
+	Value_json    JSONValue      `tfsdk:"value_json"`
+	URL           types.String   `tfsdk:"url"`
+	ETag          types.String   `tfsdk:"etag"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// A side table's values are keyed by the id of an entity stored elsewhere, so any value is acceptable.
+var sideTableEntryKind = entryKind{
+	typeName:  "_side_table_entry",
+	container: "side_table",
+	noun:      "side table entry",
+	model:     func(m *EntryModel) interface{} { return (*SideTableEntryModel)(m) },
+
+	description:          "The value associated with a single entity in a bosk SideTable. Bosk serializes a side table as `{\"domain\":...,\"valuesById\":[{\"<id>\":<value>},...]}`; this resource owns just one of those entries, so different configurations can manage different entries of the same side table.",
+	idDescription:        "The id of the entity whose value this is. Changing it replaces the entry.",
+	valueJSONDescription: "The JSON-encoded value associated with the entity.",
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccSideTableEntryResource(t *testing.T) {
+	fake := newFakeBoskHandler(t)
+	fake.set("/bosk/settings/other", `{"color":"blue"}`)
+	testServer := httptest.NewServer(requireAuthorization(func(header string) bool { return header == "Bearer abc" }, fake))
+	defer testServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSideTableEntryResourceConfig(testServer.URL+"/bosk/", "earth", "green"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bosk_side_table_entry.test", "side_table_url", testServer.URL+"/bosk/settings"),
+					resource.TestCheckResourceAttr("bosk_side_table_entry.test", "url", testServer.URL+"/bosk/settings/earth"),
+					resource.TestCheckResourceAttr("bosk_side_table_entry.test", "value_json", `{"color":"green"}`),
+					resource.TestCheckResourceAttrSet("bosk_side_table_entry.test", "etag"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bosk_side_table_entry.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     "settings/earth",
+			},
+			// Out-of-band changes are detected and corrected
+			{
+				PreConfig: func() {
+					fake.set("/bosk/settings/earth", `{"color":"red"}`)
+				},
+				Config: testAccSideTableEntryResourceConfig(testServer.URL+"/bosk/", "earth", "green"),
+				Check: func(*terraform.State) error {
+					if value, _ := fake.get("/bosk/settings/earth"); !jsonEquivalent(value, `{"color":"green"}`) {
+						return fmt.Errorf("drift was not corrected; got %s", value)
+					}
+					return nil
+				},
+			},
+			// Update testing
+			{
+				Config: testAccSideTableEntryResourceConfig(testServer.URL+"/bosk/", "earth", "yellow"),
+				Check:  resource.TestCheckResourceAttr("bosk_side_table_entry.test", "value_json", `{"color":"yellow"}`),
+			},
+			// A new id is a new entry
+			{
+				Config: testAccSideTableEntryResourceConfig(testServer.URL+"/bosk/", "mars", "red"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bosk_side_table_entry.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: func(*terraform.State) error {
+					if _, ok := fake.get("/bosk/settings/earth"); ok {
+						return fmt.Errorf("old entry was left behind")
+					}
+					if _, ok := fake.get("/bosk/settings/mars"); !ok {
+						return fmt.Errorf("new entry was not created")
+					}
+					return nil
+				},
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			if _, ok := fake.get("/bosk/settings/mars"); ok {
+				return fmt.Errorf("entry was not deleted")
+			}
+			if _, ok := fake.get("/bosk/settings/other"); !ok {
+				return fmt.Errorf("unmanaged entry was deleted")
+			}
+			return nil
+		},
+	})
+}
+
+func testAccSideTableEntryResourceConfig(base, id, color string) string {
+	return fmt.Sprintf(`
+		provider "bosk" {
+			base_url = "%s"
+			auth {
+				bearer {
+					token = "abc"
+				}
+			}
+		}
+		resource "bosk_side_table_entry" "test" {
+			side_table_path = "settings"
+			id              = "%s"
+			value_json      = jsonencode({ color = "%s" })
+		}
+	`, base, id, color)
+}
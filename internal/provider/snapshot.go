@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// snapshotFormatVersion identifies the layout of snapshotArchive, and changes whenever
+// a change to the layout would prevent an older restore from interpreting it correctly.
+const snapshotFormatVersion = 1
+
+// snapshotArchive is the file written by the snapshot subcommand.
+type snapshotArchive struct {
+	FormatVersion int    `json:"format_version"`
+	URL           string `json:"url"`
+	Path          string `json:"path"`
+	Revision      string `json:"revision,omitempty"`
+	TakenAt       string `json:"taken_at"`
+
+	// Checksum is the SHA-256 of Value in the canonical form produced by normalizeJSON,
+	// so it survives reformatting of the archive.
+	Checksum string          `json:"checksum"`
+	Value    json.RawMessage `json:"value"`
+}
+
+func snapshotChecksum(canonicalValue []byte) string {
+	sum := sha256.Sum256(canonicalValue)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Snapshot implements the "snapshot" subcommand of the provider binary.
+// It writes the bosk subtree at a given path to an archive file that Restore can put back.
+func Snapshot(ctx context.Context, args []string, stderr io.Writer) error {
+	flags := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var clientFlags cliClientFlags
+	clientFlags.register(flags)
+	nodePath := flags.String("path", "/", "the bosk path of the subtree to snapshot, relative to the base URL")
+	outFile := flags.String("out", "", "the archive file to write; must not already exist")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+	if *outFile == "" {
+		return fmt.Errorf("-out is required")
+	}
+
+	var diags diag.Diagnostics
+	client := clientFlags.newClient(&diags)
+	if err := diagnosticsError(diags, stderr); err != nil {
+		return err
+	}
+
+	root := normalizePath(*nodePath)
+	url := client.URLForPath(root)
+	valueJSON, revision := client.GetJSONAsString(ctx, url, &diags)
+	if err := diagnosticsError(diags, stderr); err != nil {
+		return err
+	}
+	canonical, err := normalizeJSON([]byte(valueJSON))
+	if err != nil {
+		return fmt.Errorf("invalid JSON at %v: %w", url, err)
+	}
+
+	archive, err := json.MarshalIndent(snapshotArchive{
+		FormatVersion: snapshotFormatVersion,
+		URL:           url,
+		Path:          root,
+		Revision:      revision,
+		TakenAt:       time.Now().UTC().Format(time.RFC3339),
+		Checksum:      snapshotChecksum(canonical),
+		Value:         canonical,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeNewFile(*outFile, string(archive)+"\n"); err != nil {
+		return err
+	}
+	fmt.Fprintf(stderr, "Saved snapshot of %s to %s\n", url, *outFile)
+	return nil
+}
+
+// Restore implements the "restore" subcommand of the provider binary.
+// It lists the nodes whose current values differ from those in a snapshot archive on stdout,
+// then, unless it's a dry run, puts the archived value back with a single PUT.
+func Restore(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var clientFlags cliClientFlags
+	clientFlags.register(flags)
+	inFile := flags.String("in", "", "the archive file written by snapshot")
+	nodePath := flags.String("path", "", "the bosk path to restore to, relative to the base URL; defaults to the path in the archive")
+	dryRun := flags.Bool("dry-run", false, "list the nodes that differ from the archive without changing anything")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+	if *inFile == "" {
+		return fmt.Errorf("-in is required")
+	}
+
+	archive, desiredJSON, err := readSnapshotArchive(*inFile)
+	if err != nil {
+		return err
+	}
+
+	var diags diag.Diagnostics
+	client := clientFlags.newClient(&diags)
+	if err := diagnosticsError(diags, stderr); err != nil {
+		return err
+	}
+
+	root := archive.Path
+	if *nodePath != "" {
+		root = normalizePath(*nodePath)
+	}
+	url := client.URLForPath(root)
+	if url != archive.URL {
+		fmt.Fprintf(stderr, "Restoring snapshot of %s to %s\n", archive.URL, url)
+	}
+
+	current := client.GetNode(ctx, url, &diags)
+	if err := diagnosticsError(diags, stderr); err != nil {
+		return err
+	}
+	desired, err := decodeJSONLosslessly(desiredJSON)
+	if err != nil {
+		return err
+	}
+	var differences []string
+	if current.Found {
+		currentValue, err := decodeJSONLosslessly([]byte(current.JSON))
+		if err != nil {
+			return fmt.Errorf("invalid JSON at %v: %w", url, err)
+		}
+		diffNodes(root, currentValue, desired, &differences)
+	} else {
+		differences = append(differences, "+ "+root)
+	}
+
+	for _, difference := range differences {
+		fmt.Fprintln(stdout, difference)
+	}
+	if len(differences) == 0 {
+		fmt.Fprintf(stderr, "%s already matches the snapshot\n", url)
+		return nil
+	}
+	if *dryRun {
+		fmt.Fprintf(stderr, "Dry run: %v nodes differ from the snapshot; nothing was changed\n", len(differences))
+		return nil
+	}
+
+	// Conditional on the revision we compared against, so the differences listed are the ones we overwrote
+	client.PutJSONAsString(ctx, url, string(desiredJSON), current.Revision, &diags)
+	if err := diagnosticsError(diags, stderr); err != nil {
+		return err
+	}
+	fmt.Fprintf(stderr, "Restored %s from %s\n", url, *inFile)
+	return nil
+}
+
+// readSnapshotArchive reads and verifies an archive, returning it along with the canonical form of its value.
+func readSnapshotArchive(name string) (snapshotArchive, []byte, error) {
+	var archive snapshotArchive
+	contents, err := os.ReadFile(name)
+	if err != nil {
+		return archive, nil, err
+	}
+	if err := json.Unmarshal(contents, &archive); err != nil {
+		return archive, nil, fmt.Errorf("%s is not a snapshot archive: %w", name, err)
+	}
+	if archive.FormatVersion != snapshotFormatVersion {
+		return archive, nil, fmt.Errorf("%s has format version %v; expected %v", name, archive.FormatVersion, snapshotFormatVersion)
+	}
+	if archive.Value == nil || archive.Path == "" {
+		return archive, nil, fmt.Errorf("%s is missing its path or value", name)
+	}
+	canonical, err := normalizeJSON(archive.Value)
+	if err != nil {
+		return archive, nil, fmt.Errorf("%s has an invalid value: %w", name, err)
+	}
+	if checksum := snapshotChecksum(canonical); checksum != archive.Checksum {
+		return archive, nil, fmt.Errorf("%s is corrupt: expected checksum %s; got %s", name, archive.Checksum, checksum)
+	}
+	return archive, canonical, nil
+}
+
+// diffNodes appends a line to differences for each node under nodePath that would change
+// if current were replaced by desired: "+" for added nodes, "-" for removed ones, and "~" for changed ones.
+// Objects are compared field by field and catalogs entry by entry; other values are compared whole.
+func diffNodes(nodePath string, current interface{}, desired interface{}, differences *[]string) {
+	if currentObject, ok := current.(map[string]interface{}); ok && !isListingOrSideTable(currentObject) {
+		if desiredObject, ok := desired.(map[string]interface{}); ok && !isListingOrSideTable(desiredObject) {
+			diffMembers(nodePath, currentObject, desiredObject, sortedKeys(currentObject), sortedKeys(desiredObject), differences)
+			return
+		}
+	}
+	if currentEntries, ok := entityCatalogEntriesOf(current); ok {
+		if desiredEntries, ok := entityCatalogEntriesOf(desired); ok {
+			currentMembers, currentIDs := catalogMembers(currentEntries)
+			desiredMembers, desiredIDs := catalogMembers(desiredEntries)
+			diffMembers(nodePath, currentMembers, desiredMembers, currentIDs, desiredIDs, differences)
+			if !sameOrder(currentIDs, desiredIDs) {
+				*differences = append(*differences, "~ "+nodePath+" (order)")
+			}
+			return
+		}
+	}
+	if !jsonValuesEqual(current, desired) {
+		*differences = append(*differences, "~ "+nodePath)
+	}
+}
+
+// diffMembers compares the fields of an object or the entries of a catalog, visiting them in the given orders.
+func diffMembers(nodePath string, current map[string]interface{}, desired map[string]interface{}, currentOrder []string, desiredOrder []string, differences *[]string) {
+	for _, name := range desiredOrder {
+		if currentValue, ok := current[name]; ok {
+			diffNodes(childPath(nodePath, name), currentValue, desired[name], differences)
+		} else {
+			*differences = append(*differences, "+ "+childPath(nodePath, name))
+		}
+	}
+	for _, name := range currentOrder {
+		if _, ok := desired[name]; !ok {
+			*differences = append(*differences, "- "+childPath(nodePath, name))
+		}
+	}
+}
+
+func catalogMembers(entries []catalogEntry) (map[string]interface{}, []string) {
+	members := make(map[string]interface{}, len(entries))
+	ids := make([]string, len(entries))
+	for i, entry := range entries {
+		members[entry.ID] = entry.Value
+		ids[i] = entry.ID
+	}
+	return members, ids
+}
+
+// sameOrder reports whether the ids common to both lists appear in the same relative order.
+func sameOrder(a []string, b []string) bool {
+	common := func(ids []string, others []string) []string {
+		present := make(map[string]bool, len(others))
+		for _, id := range others {
+			present[id] = true
+		}
+		var result []string
+		for _, id := range ids {
+			if present[id] {
+				result = append(result, id)
+			}
+		}
+		return result
+	}
+	a, b = common(a, b), common(b, a)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
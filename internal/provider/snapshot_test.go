@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiffNodes(t *testing.T) {
+	current := `{
+		"name": "demo",
+		"worlds": [{"earth": {"id": "earth", "moons": 1}}, {"mars": {"id": "mars"}}, {"pluto": {"id": "pluto"}}],
+		"planets": {"ids": ["earth"], "domain": "/worlds"},
+		"tags": ["a", "b"],
+		"pairs": [{"x": 1}, {"y": 2}]
+	}`
+	desired := `{
+		"name": "demo",
+		"worlds": [{"mars": {"id": "mars"}}, {"earth": {"id": "earth", "moons": 1.0}}, {"a/b": {"id": "a/b"}}],
+		"planets": {"ids": ["earth", "mars"], "domain": "/worlds"},
+		"tags": ["b", "a"],
+		"pairs": [{"y": 2}, {"x": 1}],
+		"extra": {}
+	}`
+	currentValue, _ := decodeJSONLosslessly([]byte(current))
+	desiredValue, _ := decodeJSONLosslessly([]byte(desired))
+	var differences []string
+	diffNodes("/", currentValue, desiredValue, &differences)
+	expected := []string{
+		"+ /extra",
+		"~ /pairs",
+		"~ /planets",
+		"~ /tags",
+		"+ /worlds/a%2Fb",
+		"- /worlds/pluto",
+		"~ /worlds (order)",
+	}
+	if strings.Join(differences, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(differences, "\n"))
+	}
+
+	differences = nil
+	diffNodes("/", currentValue, currentValue, &differences)
+	if len(differences) != 0 {
+		t.Errorf("expected no differences; got %v", differences)
+	}
+}
+
+func TestSnapshotAndRestore(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+	original := `{"name":"demo","worlds":[{"earth":{"id":"earth"}}]}`
+	fake.set("/bosk/state", original)
+
+	clientArgs := []string{"-base-url", testServer.URL + "/bosk", "-basic-auth-var-suffix", "NO_AUTH"}
+	archiveFile := filepath.Join(t.TempDir(), "state.json")
+	var stdout, stderr bytes.Buffer
+
+	err := Snapshot(context.Background(), append(clientArgs, "-path", "state", "-out", archiveFile), &stderr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	archive, _, err := readSnapshotArchive(archiveFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if archive.URL != testServer.URL+"/bosk/state" || archive.Path != "/state" || archive.Revision == "" {
+		t.Errorf("unexpected archive metadata: %+v", archive)
+	}
+
+	fake.set("/bosk/state", `{"name":"changed","worlds":[{"earth":{"id":"earth"}},{"mars":{"id":"mars"}}]}`)
+
+	err = Restore(context.Background(), append(clientArgs, "-in", archiveFile, "-dry-run"), &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "~ /state/name\n- /state/worlds/mars\n"; stdout.String() != expected {
+		t.Errorf("expected dry run output\n%s\ngot\n%s", expected, stdout.String())
+	}
+	if value, _ := fake.get("/bosk/state"); value == original {
+		t.Errorf("expected dry run not to change the node")
+	}
+
+	stdout.Reset()
+	err = Restore(context.Background(), append(clientArgs, "-in", archiveFile), &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value, _ := fake.get("/bosk/state"); value != original {
+		t.Errorf("expected %s; got %s", original, value)
+	}
+
+	stdout.Reset()
+	err = Restore(context.Background(), append(clientArgs, "-in", archiveFile), &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stdout.Len() != 0 || !strings.Contains(stderr.String(), "already matches") {
+		t.Errorf("expected no differences; got %q", stdout.String())
+	}
+}
+
+func TestRestoreToAbsentNode(t *testing.T) {
+	fake, testServer := newFakeBosk(t)
+	defer testServer.Close()
+	fake.set("/bosk/state", `{"name":"demo"}`)
+
+	clientArgs := []string{"-base-url", testServer.URL + "/bosk", "-basic-auth-var-suffix", "NO_AUTH"}
+	archiveFile := filepath.Join(t.TempDir(), "state.json")
+	var stdout, stderr bytes.Buffer
+	if err := Snapshot(context.Background(), append(clientArgs, "-path", "/state", "-out", archiveFile), &stderr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := Restore(context.Background(), append(clientArgs, "-in", archiveFile, "-path", "/copy"), &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stdout.String() != "+ /copy\n" {
+		t.Errorf("expected the whole node to be added; got %q", stdout.String())
+	}
+	if value, _ := fake.get("/bosk/copy"); value != `{"name":"demo"}` {
+		t.Errorf("expected the snapshot to be restored to /copy; got %s", value)
+	}
+}
+
+func TestReadSnapshotArchiveDetectsCorruption(t *testing.T) {
+	archiveFile := filepath.Join(t.TempDir(), "state.json")
+	archive := `{
+  "format_version": 1,
+  "url": "http://localhost:1740/bosk/state",
+  "path": "/state",
+  "taken_at": "2024-01-01T00:00:00Z",
+  "checksum": "` + snapshotChecksum([]byte(`{"name":"demo"}`)) + `",
+  "value": {"name": "demo"}
+}`
+	if err := os.WriteFile(archiveFile, []byte(archive), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := readSnapshotArchive(archiveFile); err != nil {
+		t.Errorf("expected a reformatted archive to verify; got %v", err)
+	}
+
+	if err := os.WriteFile(archiveFile, []byte(strings.Replace(archive, `"demo"}`, `"tampered"}`, 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := readSnapshotArchive(archiveFile); err == nil || !strings.Contains(err.Error(), "corrupt") {
+		t.Errorf("expected a checksum error; got %v", err)
+	}
+
+	if err := os.WriteFile(archiveFile, []byte(strings.Replace(archive, `"format_version": 1`, `"format_version": 2`, 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := readSnapshotArchive(archiveFile); err == nil || !strings.Contains(err.Error(), "format version") {
+		t.Errorf("expected a format version error; got %v", err)
+	}
+}
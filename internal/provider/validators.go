@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = httpURLValidator{}
+var _ validator.String = jsonValidator{}
+
+// httpURLValidator checks that a string is an absolute http or https URL.
+type httpURLValidator struct{}
+
+func (v httpURLValidator) Description(ctx context.Context) string {
+	return "value must be an absolute http or https URL"
+}
+
+func (v httpURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v httpURLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if problem := checkHTTPURL(req.ConfigValue.ValueString()); problem != "" {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid URL", problem)
+	}
+}
+
+// checkHTTPURL returns a description of what's wrong with the given URL, or "" if nothing is.
+func checkHTTPURL(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return fmt.Sprintf("Unable to parse URL %q: %s", value, errors.Unwrap(err))
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Sprintf("Expected URL to start with either \"http://\" or \"https://\". Got: %v", value)
+	}
+	if parsed.Host == "" {
+		return fmt.Sprintf("Expected URL to include a host. Got: %v", value)
+	}
+	return ""
+}
+
+// jsonValidator checks that a string is a single well-formed JSON value.
+type jsonValidator struct{}
+
+func (v jsonValidator) Description(ctx context.Context) string {
+	return "value must be valid JSON"
+}
+
+func (v jsonValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if problem := checkJSON([]byte(req.ConfigValue.ValueString())); problem != "" {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid JSON", problem)
+	}
+}
+
+// checkJSON returns a description of where the data fails to parse as JSON, or "" if it doesn't.
+func checkJSON(data []byte) string {
+	var value interface{}
+	err := json.Unmarshal(data, &value)
+	if err == nil {
+		return ""
+	}
+	var syntaxError *json.SyntaxError
+	if errors.As(err, &syntaxError) {
+		line, column := textPosition(data, syntaxError.Offset)
+		return fmt.Sprintf("Syntax error at line %v, column %v: %s", line, column, syntaxError)
+	}
+	return fmt.Sprintf("Unable to parse JSON: %s", err)
+}
+
+// textPosition converts the offset reported by encoding/json, which counts the bytes
+// read up to and including the offending one, into a one-based line and column.
+func textPosition(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	consumed := data[:offset]
+	line := bytes.Count(consumed, []byte("\n")) + 1
+	lineStart := bytes.LastIndexByte(consumed, '\n') + 1
+	column := utf8.RuneCount(consumed[lineStart:])
+	if column == 0 {
+		// The offending byte is a newline, or the input is empty
+		column = 1
+	}
+	return line, column
+}
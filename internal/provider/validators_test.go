@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCheckHTTPURL(t *testing.T) {
+	tests := []struct {
+		url   string
+		valid bool
+	}{
+		{"http://localhost:1740/bosk/world", true},
+		{"https://bosk.example/bosk", true},
+		{"ftp://bosk.example/bosk", false},
+		{"localhost:1740/bosk", false},
+		{"/bosk/world", false},
+		{"http:///bosk/world", false},
+		{"http://localhost:1740/%zz", false},
+		{"http://local host/", false},
+	}
+	for _, test := range tests {
+		problem := checkHTTPURL(test.url)
+		if test.valid && problem != "" {
+			t.Errorf("%q: expected valid; got %v", test.url, problem)
+		} else if !test.valid && problem == "" {
+			t.Errorf("%q: expected a problem", test.url)
+		}
+	}
+}
+
+func TestCheckJSON(t *testing.T) {
+	tests := []struct {
+		json     string
+		expected string
+	}{
+		{`{"a": [1, 2.5, "x", true, null]}`, ``},
+		{`"just a string"`, ``},
+		{`{"a": 1,}`, `line 1, column 9`},
+		{"{\n  \"a\": 1\n  \"b\": 2\n}", `line 3, column 3`},
+		{"{\n  \"é\": nope\n}", `line 2, column 9`},
+		{`{"a": 1`, `line 1, column 7`},
+		{``, `line 1, column 1`},
+		{`{} {}`, `line 1, column 4`},
+	}
+	for _, test := range tests {
+		problem := checkJSON([]byte(test.json))
+		if test.expected == "" {
+			if problem != "" {
+				t.Errorf("%q: expected valid; got %v", test.json, problem)
+			}
+		} else if !strings.Contains(problem, test.expected) {
+			t.Errorf("%q: expected problem at %v; got %q", test.json, test.expected, problem)
+		}
+	}
+}
+
+func TestValidatorsSkipUnknownValues(t *testing.T) {
+	for _, v := range []validator.String{httpURLValidator{}, jsonValidator{}} {
+		for _, value := range []types.String{types.StringUnknown(), types.StringNull()} {
+			req := validator.StringRequest{Path: path.Root("attr"), ConfigValue: value}
+			var resp validator.StringResponse
+			v.ValidateString(context.Background(), req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Errorf("%T: expected %v to be skipped; got %v", v, value, resp.Diagnostics)
+			}
+		}
+	}
+}
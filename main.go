@@ -7,6 +7,7 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/prdoyle/terraform-provider-bosk/internal/provider"
@@ -31,7 +32,29 @@ var (
 	// https://goreleaser.com/cookbooks/using-main.version/
 )
 
+// subcommands run instead of the provider server when named by the first argument.
+var subcommands = map[string]func(ctx context.Context, args []string) error{
+	"generate": func(ctx context.Context, args []string) error {
+		return provider.Generate(ctx, args, os.Stderr)
+	},
+	"snapshot": func(ctx context.Context, args []string) error {
+		return provider.Snapshot(ctx, args, os.Stderr)
+	},
+	"restore": func(ctx context.Context, args []string) error {
+		return provider.Restore(ctx, args, os.Stdout, os.Stderr)
+	},
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if subcommand, ok := subcommands[os.Args[1]]; ok {
+			if err := subcommand(context.Background(), os.Args[2:]); err != nil {
+				log.Fatal(err.Error())
+			}
+			return
+		}
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve; also PAT WAS HERE")